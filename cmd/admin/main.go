@@ -0,0 +1,63 @@
+// Command admin menjalankan tugas operasional yang belum punya endpoint
+//
+// Penggunaan:
+//
+//	go run ./cmd/admin verify-capacity <event-id> <paid-price>
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/fzndps/eventcheck/config"
+	"github.com/fzndps/eventcheck/internal/infrastructure/database"
+	"github.com/fzndps/eventcheck/internal/repository/mysql"
+	"github.com/fzndps/eventcheck/internal/usecase"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("Usage: admin verify-capacity <event-id> <paid-price>")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	db, err := database.InitDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect database:", err)
+	}
+
+	defer db.Close()
+
+	ctx := context.Background()
+
+	switch task := os.Args[1]; task {
+	case "verify-capacity":
+		// Kuota tambahan baru aktif setelah selisih harga yang dibayar organizer diverifikasi
+		if len(os.Args) != 4 {
+			log.Fatal("Usage: admin verify-capacity <event-id> <paid-price>")
+		}
+
+		paidPrice, err := strconv.Atoi(os.Args[3])
+		if err != nil {
+			log.Fatalf("Invalid paid price %q", os.Args[3])
+		}
+
+		eventUsecase := usecase.NewEventUsecase(mysql.NewEventRepository(db), mysql.NewParticipantRepository(db), cfg)
+
+		res, err := eventUsecase.VerifyCapacityPayment(ctx, os.Args[2], paidPrice)
+		if err != nil {
+			log.Fatal("Failed to verify capacity payment:", err)
+		}
+		fmt.Printf("Event %s now has %d participants quota (total price %d)\n", os.Args[2], res.ParticipantCount, res.TotalPrice)
+
+	default:
+		log.Fatalf("Unknown admin task %q", task)
+	}
+}
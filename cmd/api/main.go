@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/fzndps/eventcheck/internal/repository/mysql"
 	"github.com/fzndps/eventcheck/internal/usecase"
 	"github.com/fzndps/eventcheck/pkg/jwt"
+	"github.com/fzndps/eventcheck/pkg/pubsub"
 )
 
 func main() {
@@ -34,21 +36,34 @@ func main() {
 
 	emailService := email.NewEmailService(&cfg.SMTP)
 
+	// Hub untuk live attendance stream ke dashboard
+	attendanceHub := pubsub.NewHub()
+
 	// initialize repo layer
 	organizerRepo := mysql.NewOrganizerRepositoryImpl(db)
 	eventRepo := mysql.NewEventRepository(db)
 	participantRepo := mysql.NewParticipantRepository(db)
+	checkInLogRepo := mysql.NewCheckInLogRepository(db)
+	importPreviewRepo := mysql.NewImportPreviewRepository(db)
+	importJobRepo := mysql.NewImportJobRepository(db)
 
 	// Initialize service/usecase layer
 	authUsecase := usecase.NewAuthUsecase(organizerRepo, jwtManager, cfg)
-	eventUsecase := usecase.NewEventUsecase(eventRepo, participantRepo)
-	participantUsecase := usecase.NewParticipantUsecase(eventRepo, participantRepo)
+	eventUsecase := usecase.NewEventUsecase(eventRepo, participantRepo, cfg)
+	participantUsecase := usecase.NewParticipantUsecase(eventRepo, participantRepo, organizerRepo, importPreviewRepo, importJobRepo, cfg.Phone.DefaultRegion)
 	qrEmailUsecase := usecase.NewQREmailUsecase(eventRepo, participantRepo, qrGenerator, emailService)
+	checkInUsecase := usecase.NewCheckInUsecase(eventRepo, participantRepo, checkInLogRepo, attendanceHub, cfg.Phone.DefaultRegion)
+	scannerUsecase := usecase.NewScannerUsecase(eventRepo, jwtManager, cfg)
+
+	// Worker untuk import participant dari file besar
+	participantUsecase.StartImportWorker(context.Background())
 
 	// initialize handler layer
 	authHandler := http.NewAutHandler(authUsecase)
 	eventHandler := http.NewEventHandler(*eventUsecase, participantUsecase)
 	qrEmailHandler := http.NewQREmailHandler(qrEmailUsecase)
+	checkInHandler := http.NewCheckInHandler(checkInUsecase)
+	scannerHandler := http.NewScannerHandler(scannerUsecase)
 
 	authMiddleware := middleware.NewAuthMiddleware(jwtManager)
 
@@ -56,6 +71,8 @@ func main() {
 		AuthHandler:    authHandler,
 		EventHandler:   eventHandler,
 		QREmailHandler: qrEmailHandler,
+		CheckInHandler: checkInHandler,
+		ScannerHandler: scannerHandler,
 		AuthMiddleware: authMiddleware,
 	})
 
@@ -0,0 +1,73 @@
+// Command backfill menjalankan migrasi data sekali jalan yang tidak bisa ditulis dalam SQL
+//
+// Penggunaan:
+//
+//	go run ./cmd/backfill scanner-pins
+//	go run ./cmd/backfill phones
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/fzndps/eventcheck/config"
+	"github.com/fzndps/eventcheck/internal/infrastructure/database"
+	"github.com/fzndps/eventcheck/internal/repository/mysql"
+	"github.com/fzndps/eventcheck/internal/usecase"
+	"github.com/fzndps/eventcheck/pkg/jwt"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatal("Usage: backfill scanner-pins|phones")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	db, err := database.InitDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect database:", err)
+	}
+
+	defer db.Close()
+
+	ctx := context.Background()
+	eventRepo := mysql.NewEventRepository(db)
+
+	switch task := os.Args[1]; task {
+	case "scanner-pins":
+		// Scanner PIN event lama harus di-hash sebelum versi tanpa fallback plain text di-deploy
+		scannerUsecase := usecase.NewScannerUsecase(eventRepo, jwt.NewJWTManager(cfg.JWT.Secret), cfg)
+
+		hashed, err := scannerUsecase.HashPlainPINs(ctx)
+		if err != nil {
+			log.Fatalf("Failed to hash scanner PINs after %d events: %v", hashed, err)
+		}
+		fmt.Printf("Hashed plain text scanner PIN of %d events\n", hashed)
+
+	case "phones":
+		// Phone participant lama dinormalisasi dengan region yang sama dengan import
+		participantUsecase := usecase.NewParticipantUsecase(
+			eventRepo,
+			mysql.NewParticipantRepository(db),
+			mysql.NewOrganizerRepositoryImpl(db),
+			mysql.NewImportPreviewRepository(db),
+			mysql.NewImportJobRepository(db),
+			cfg.Phone.DefaultRegion,
+		)
+
+		normalized, invalid, err := participantUsecase.NormalizePhones(ctx)
+		if err != nil {
+			log.Fatalf("Failed to normalize participant phones after %d participants: %v", normalized+invalid, err)
+		}
+		fmt.Printf("Normalized phone of %d participants, %d could not be normalized\n", normalized, invalid)
+
+	default:
+		log.Fatalf("Unknown backfill task %q", task)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/fzndps/eventcheck/pkg/phone"
 	"github.com/joho/godotenv"
 )
 
@@ -14,6 +16,8 @@ type Config struct {
 	App      AppConfig
 	JWT      JWTConfig
 	SMTP     SMTPConfig
+	Scanner  ScannerConfig
+	Phone    PhoneConfig
 }
 
 type DatabaseConfig struct {
@@ -33,6 +37,20 @@ type JWTConfig struct {
 	Expiry int
 }
 
+// ScannerConfig konfigurasi untuk scanner session (login dengan scanner PIN)
+type ScannerConfig struct {
+	TokenExpiry         int // dalam jam
+	PINLength           int // 4 sampai 8 digit
+	MaxAttemptsPerIP    int // percobaan PIN gagal per IP sebelum dikunci
+	MaxAttemptsPerEvent int // percobaan PIN gagal per event dari satu range IP sebelum dikunci
+	LockoutMinutes      int
+}
+
+// PhoneConfig konfigurasi normalisasi nomor telepon participant
+type PhoneConfig struct {
+	DefaultRegion string // kode negara ISO 3166 untuk nomor tanpa kode negara, misalnya ID
+}
+
 type SMTPConfig struct {
 	SMTPHost     string
 	SMTPPort     int
@@ -71,6 +89,18 @@ func LoadConfig(filename ...string) (*Config, error) {
 			SMTPPassword: os.Getenv("SMTP_PASSWORD"),
 			SMTPFrom:     os.Getenv("SMTP_FROM"),
 		},
+
+		Scanner: ScannerConfig{
+			TokenExpiry:         getEnvAsInt("SCANNER_TOKEN_EXPIRY", 12),
+			PINLength:           getEnvAsInt("SCANNER_PIN_LENGTH", 6),
+			MaxAttemptsPerIP:    getEnvAsInt("SCANNER_MAX_ATTEMPTS_PER_IP", 5),
+			MaxAttemptsPerEvent: getEnvAsInt("SCANNER_MAX_ATTEMPTS_PER_EVENT", 20),
+			LockoutMinutes:      getEnvAsInt("SCANNER_LOCKOUT_MINUTES", 15),
+		},
+
+		Phone: PhoneConfig{
+			DefaultRegion: strings.ToUpper(getEnv("PHONE_DEFAULT_REGION", phone.DefaultRegion)),
+		},
 	}
 
 	if err := config.Validate(); err != nil {
@@ -105,6 +135,16 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("APP_PORT is required")
 	}
 
+	// Cek scanner PIN config
+	if c.Scanner.PINLength < 4 || c.Scanner.PINLength > 8 {
+		return fmt.Errorf("SCANNER_PIN_LENGTH must be between 4 and 8")
+	}
+
+	// Cek default region nomor telepon
+	if !phone.IsSupportedRegion(c.Phone.DefaultRegion) {
+		return fmt.Errorf("PHONE_DEFAULT_REGION %q is not a supported region", c.Phone.DefaultRegion)
+	}
+
 	// Cek email config (jika ingin kirim email)
 	if c.SMTP.SMTPHost == "" {
 		return fmt.Errorf("SMTP_HOST is required")
@@ -131,6 +171,14 @@ func (c *Config) GetDSN() string {
 	)
 }
 
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	valueSTR := os.Getenv(key)
 
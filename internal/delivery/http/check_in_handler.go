@@ -0,0 +1,407 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/delivery/http/middleware"
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/usecase"
+	"github.com/fzndps/eventcheck/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+// liveHeartbeatInterval jeda komentar heartbeat supaya koneksi SSE tidak ditutup proxy
+const liveHeartbeatInterval = 20 * time.Second
+
+type CheckInHandler struct {
+	checkInUsecase *usecase.CheckInUsecase
+}
+
+func NewCheckInHandler(checkInUsecase *usecase.CheckInUsecase) *CheckInHandler {
+	return &CheckInHandler{
+		checkInUsecase: checkInUsecase,
+	}
+}
+
+func (h *CheckInHandler) CheckIn(c *gin.Context) {
+	// Dapatkan actor (organizer atau scanner session) dari context
+	actor, exists := middleware.GetActor(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Bind JSON request
+	var req domain.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	// panggil usecase
+	response, err := h.checkInUsecase.CheckIn(c.Request.Context(), actor, eventID, &req)
+	if err != nil {
+		if isScanConflict(err) {
+			validator.ConflictResponse(c, err.Error(), response)
+			return
+		}
+
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participant checked in successfully", response)
+}
+
+// CheckOut mencatat participant keluar dari venue
+func (h *CheckInHandler) CheckOut(c *gin.Context) {
+	// Dapatkan actor (organizer atau scanner session) dari context
+	actor, exists := middleware.GetActor(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Bind JSON request
+	var req domain.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	// panggil usecase
+	response, err := h.checkInUsecase.CheckOut(c.Request.Context(), actor, eventID, &req.ScanInfo)
+	if err != nil {
+		if isScanConflict(err) {
+			validator.ConflictResponse(c, err.Error(), response)
+			return
+		}
+
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participant checked out successfully", response)
+}
+
+func (h *CheckInHandler) Lookup(c *gin.Context) {
+	// Dapatkan actor (organizer atau scanner session) dari context
+	actor, exists := middleware.GetActor(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Bind JSON request
+	var req domain.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	// panggil usecase
+	response, err := h.checkInUsecase.Lookup(c.Request.Context(), actor, eventID, req.QRToken)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participant retrieved successfully", response)
+}
+
+// ManualCheckIn mencatat check-in participant yang dipilih dari hasil pencarian
+func (h *CheckInHandler) ManualCheckIn(c *gin.Context) {
+	// Dapatkan actor (organizer atau scanner session) dari context
+	actor, exists := middleware.GetActor(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Bind JSON request
+	var req domain.ManualCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	// panggil usecase
+	response, err := h.checkInUsecase.ManualCheckIn(c.Request.Context(), actor, eventID, &req)
+	if err != nil {
+		if isScanConflict(err) {
+			validator.ConflictResponse(c, err.Error(), response)
+			return
+		}
+
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participant checked in successfully", response)
+}
+
+// Undo membatalkan check-in participant dengan alasan
+func (h *CheckInHandler) Undo(c *gin.Context) {
+	// Dapatkan actor (organizer atau scanner session) dari context
+	actor, exists := middleware.GetActor(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Bind JSON request
+	var req domain.UndoCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	// panggil usecase
+	response, err := h.checkInUsecase.Undo(c.Request.Context(), actor, eventID, &req)
+	if err != nil {
+		if isScanConflict(err) {
+			validator.ConflictResponse(c, err.Error(), response)
+			return
+		}
+
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Check-in undone successfully", response)
+}
+
+// Search mencari participant berdasarkan nama, email atau phone untuk check-in manual
+func (h *CheckInHandler) Search(c *gin.Context) {
+	// Dapatkan actor (organizer atau scanner session) dari context
+	actor, exists := middleware.GetActor(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Bind query string
+	var req domain.ParticipantSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	// panggil usecase
+	response, err := h.checkInUsecase.Search(c.Request.Context(), actor, eventID, &req)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participants retrieved successfully", response)
+}
+
+// SyncOffline menerima batch check-in yang dicatat scanner saat offline
+func (h *CheckInHandler) SyncOffline(c *gin.Context) {
+	// Dapatkan actor (organizer atau scanner session) dari context
+	actor, exists := middleware.GetActor(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Bind JSON request
+	var req domain.OfflineCheckInBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	// panggil usecase
+	response, err := h.checkInUsecase.SyncOffline(c.Request.Context(), actor, eventID, req.Records)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Offline check-ins synced successfully", response)
+}
+
+// ListLogs menampilkan check-in log di event
+func (h *CheckInHandler) ListLogs(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	// Dapatkan pagination parameter dari query string
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	response, err := h.checkInUsecase.ListLogs(c.Request.Context(), organizerID, eventID, page, limit)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Check-in logs retrieved successfully", response)
+}
+
+// ListParticipantLogs menampilkan check-in log milik satu participant
+func (h *CheckInHandler) ListParticipantLogs(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dan participant ID dari URL
+	eventID := c.Param("eventID")
+	participantID, err := strconv.ParseInt(c.Param("participantID"), 10, 64)
+	if err != nil {
+		validator.BadRequestResponse(c, "Invalid participant ID")
+		return
+	}
+
+	// Dapatkan pagination parameter dari query string
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	response, err := h.checkInUsecase.ListParticipantLogs(c.Request.Context(), organizerID, eventID, participantID, page, limit)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Check-in logs retrieved successfully", response)
+}
+
+// Live mengirim update kehadiran event secara realtime dengan Server-Sent Events
+// Event "stats" dikirim sekali saat terhubung, lalu event "scan" untuk setiap scan
+func (h *CheckInHandler) Live(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	stats, sub, err := h.checkInUsecase.WatchAttendance(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent(domain.LiveEventStats, stats)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(liveHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case update, ok := <-sub.C():
+			if !ok {
+				return false
+			}
+			c.SSEvent(domain.LiveEventScan, update)
+			return true
+		case <-heartbeat.C:
+			// Baris komentar SSE diabaikan client
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
+
+// isScanConflict true jika scan ditolak karena status participant
+// Response tetap dikirim supaya scanner bisa menampilkan status participant
+func isScanConflict(err error) bool {
+	return errors.Is(err, domain.ErrParticipantCheckedIn) ||
+		errors.Is(err, domain.ErrEntryLimitReached) ||
+		errors.Is(err, domain.ErrParticipantNotInside) ||
+		errors.Is(err, domain.ErrParticipantNotCheckedIn) ||
+		errors.Is(err, domain.ErrCheckInNotOpen) ||
+		errors.Is(err, domain.ErrCheckInClosed)
+}
+
+func (h *CheckInHandler) handleError(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidQRToken):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrParticipantWrongEvent):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, domain.ErrEventNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, "participant not found"
+	case errors.Is(err, domain.ErrUnauthorizedAccess):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, domain.ErrScannerSessionRevoked):
+		return http.StatusUnauthorized, err.Error()
+	case errors.Is(err, domain.ErrWindowOverrideForbidden):
+		return http.StatusForbidden, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
+}
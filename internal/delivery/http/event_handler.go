@@ -1,11 +1,20 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/fzndps/eventcheck/internal/delivery/http/middleware"
 	"github.com/fzndps/eventcheck/internal/domain"
 	"github.com/fzndps/eventcheck/internal/usecase"
+	"github.com/fzndps/eventcheck/pkg/csv"
 	"github.com/fzndps/eventcheck/pkg/validator"
 	"github.com/gin-gonic/gin"
 )
@@ -39,6 +48,10 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 	event, err := h.eventUsecase.CreateEvent(c.Request.Context(), organizerID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidReentryPolicy) {
+			validator.BadRequestResponse(c, err.Error())
+			return
+		}
 		validator.InternalServerErrorResponse(c, "Failed to create event")
 		return
 	}
@@ -109,6 +122,10 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	// panggil usecase
 	event, err := h.eventUsecase.UpdateEvent(c.Request.Context(), int64(organizerID), eventID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidReentryPolicy) {
+			validator.BadRequestResponse(c, err.Error())
+			return
+		}
 		validator.InternalServerErrorResponse(c, err.Error())
 		return
 	}
@@ -153,16 +170,48 @@ func (h *EventHandler) UploadParticipants(c *gin.Context) {
 	// Dapatkan parameter event ID dari URL
 	eventID := c.Param("eventID")
 
-	// dapatkan file dari multipart form
-	file, err := c.FormFile("csv")
+	// Import token dari dry-run menjalankan import tanpa upload ulang file
+	if token := c.DefaultPostForm("import_token", c.Query("import_token")); token != "" {
+		response, job, err := h.participantUsecase.ConfirmImport(c.Request.Context(), int64(organizerID), eventID, token)
+		if job != nil {
+			validator.AcceptedResponse(c, "Import job queued", job)
+			return
+		}
+
+		h.importResponse(c, response, err)
+		return
+	}
+
+	dryRun := false
+	if value := c.DefaultPostForm("dry_run", c.Query("dry_run")); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			validator.BadRequestResponse(c, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	// dapatkan file dari multipart form, key 'csv' tetap diterima untuk client lama
+	file, err := c.FormFile("file")
 	if err != nil {
-		validator.BadRequestResponse(c, "The CSV file must be uploaded with the key 'csv'")
+		file, err = c.FormFile("csv")
+	}
+	if err != nil {
+		validator.BadRequestResponse(c, "The CSV or XLSX file must be uploaded with the key 'file'")
+		return
+	}
+
+	// File import disimpan utuh di database, jadi ukurannya dibatasi
+	if file.Size > domain.MaxImportFileSize {
+		validator.BadRequestResponse(c, domain.ErrImportFileTooLarge.Error())
 		return
 	}
 
-	// Validasi file ekstensi
-	if file.Header.Get("Content-Type") != "text/csv" && file.Header.Get("Content-Type") != "application/vnd.ms-excel" {
-		validator.BadRequestResponse(c, "The file must be in CSV format")
+	// Tentukan format file dari ekstensi atau Content-Type
+	format, ok := importFileFormat(file)
+	if !ok {
+		validator.BadRequestResponse(c, "The file must be in CSV or XLSX format")
 		return
 	}
 
@@ -176,15 +225,140 @@ func (h *EventHandler) UploadParticipants(c *gin.Context) {
 	defer fileReader.Close()
 
 	// panggil usecase
-	response, err := h.participantUsecase.UploadParticipants(c.Request.Context(), int64(organizerID), eventID, fileReader)
+	// Mode import untuk participant yang sudah terdaftar: reject, skip atau update
+	opts := &domain.ImportOptions{
+		Mode:   c.DefaultPostForm("mode", c.Query("mode")),
+		Format: format,
+		Sheet:  c.DefaultPostForm("sheet", c.Query("sheet")), // Sheet xlsx, default sheet pertama
+
+		// Charset csv misalnya windows-1252, default dideteksi otomatis
+		Charset: c.DefaultPostForm("charset", c.Query("charset")),
+	}
+
+	// Mapping opsional berupa JSON {"header di file": "name|email|phone|ignore"}
+	if mapping := c.PostForm("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &opts.Mapping); err != nil {
+			validator.BadRequestResponse(c, "mapping must be a JSON object of column header to participant field")
+			return
+		}
+	}
+
+	if dryRun {
+		// Jumlah row yang ditampilkan di preview, default 20
+		limit, _ := strconv.Atoi(c.Query("preview_rows"))
+
+		preview, err := h.participantUsecase.PreviewUpload(c.Request.Context(), int64(organizerID), eventID, fileReader, opts, limit)
+		if err != nil {
+			h.importErrorResponse(c, err)
+			return
+		}
+
+		validator.SuccessResponse(c, "Import preview generated successfully", preview)
+		return
+	}
+
+	// File besar diimport di background, status dipantau lewat GET /imports/:jobID
+	// dan row yang ditolak diambil lewat GET /imports/:jobID?rejected=csv
+	if file.Size > domain.ImportJobThreshold {
+		job, err := h.participantUsecase.SubmitImportJob(c.Request.Context(), int64(organizerID), eventID, fileReader, opts)
+		if err != nil {
+			h.importErrorResponse(c, err)
+			return
+		}
+
+		validator.AcceptedResponse(c, "Import job queued", job)
+		return
+	}
+
+	response, err := h.participantUsecase.UploadParticipants(c.Request.Context(), int64(organizerID), eventID, fileReader, opts)
+	h.importResponse(c, response, err)
+}
+
+func (h *EventHandler) GetImportJob(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	job, err := h.participantUsecase.GetImportJob(c.Request.Context(), int64(organizerID), c.Param("jobID"))
 	if err != nil {
-		validator.InternalServerErrorResponse(c, err.Error())
+		if errors.Is(err, domain.ErrNotFound) {
+			validator.NotFoundResponse(c, "import job not found")
+			return
+		}
+
+		log.Print("error:", err.Error())
+		validator.InternalServerErrorResponse(c, "Failed to get import job")
+		return
+	}
+
+	// ?rejected=csv sama seperti upload biasa, hanya tersedia setelah job selesai
+	if c.Query("rejected") == "csv" {
+		if job.FinishedAt == nil {
+			validator.ConflictResponse(c, "import job is not finished yet", job)
+			return
+		}
+
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", `attachment; filename="rejected-participants.csv"`)
+		c.Header("X-Import-Success", strconv.Itoa(job.Success))
+		c.Header("X-Import-Failed", strconv.Itoa(job.Failed))
+		c.Status(http.StatusOK)
+
+		if err := csv.WriteRejectedRows(c.Writer, job.Headers, job.Errors); err != nil {
+			log.Print("error:", err.Error())
+		}
+		return
+	}
+
+	validator.SuccessResponse(c, "Import job retrieved successfully", job)
+}
+
+// importResponse mengirim hasil import participant
+// ?rejected=csv mengembalikan row yang ditolak sebagai file CSV untuk diperbaiki,
+// jumlah row yang berhasil dan gagal dikirim lewat header
+func (h *EventHandler) importResponse(c *gin.Context, response *domain.UploadParticipantsResponse, err error) {
+	if err != nil {
+		h.importErrorResponse(c, err)
+		return
+	}
+
+	if c.Query("rejected") == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", `attachment; filename="rejected-participants.csv"`)
+		c.Header("X-Import-Success", strconv.Itoa(response.Success))
+		c.Header("X-Import-Failed", strconv.Itoa(response.Failed))
+		c.Status(http.StatusOK)
+
+		if err := csv.WriteRejectedRows(c.Writer, response.Headers, response.Errors); err != nil {
+			log.Print("error:", err.Error())
+		}
 		return
 	}
 
 	validator.SuccessResponse(c, "Upload participants successfully", response)
 }
 
+func (h *EventHandler) importErrorResponse(c *gin.Context, err error) {
+	if isImportRequestError(err) {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+	if quotaExceededResponse(c, err) {
+		return
+	}
+	if errors.Is(err, domain.ErrImportTokenNotFound) {
+		validator.NotFoundResponse(c, err.Error())
+		return
+	}
+
+	log.Print("error:", err.Error())
+	statusCode, message := h.handleParticipantError(err)
+	validator.ErrorResponse(c, statusCode, message)
+}
+
 func (h *EventHandler) ListParticipant(c *gin.Context) {
 	// Dapatkan organizer id dari context
 	organizerID, exists := middleware.GetOrganizerID(c)
@@ -196,12 +370,364 @@ func (h *EventHandler) ListParticipant(c *gin.Context) {
 	// Dapatkan parameter event ID dari URL
 	eventID := c.Param("eventID")
 
+	// Dapatkan pagination, filter dan sort dari query string
+	var req domain.ParticipantListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
 	// panggil usecase
-	participants, err := h.participantUsecase.GetParticipantsByEvent(c.Request.Context(), int64(organizerID), eventID)
+	response, err := h.participantUsecase.GetParticipantsByEvent(c.Request.Context(), int64(organizerID), eventID, &req)
 	if err != nil {
-		validator.InternalServerErrorResponse(c, err.Error())
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participants retrieve successfully", response)
+}
+
+// ExportParticipants mengirim participant event sebagai file CSV atau XLSX
+// Filter dan sort sama dengan list participant, misalnya ?checked_in=false untuk peserta yang tidak hadir
+func (h *EventHandler) ExportParticipants(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	var req domain.ParticipantExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	export, err := h.participantUsecase.PrepareExport(c.Request.Context(), int64(organizerID), eventID, &req)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	c.Header("Content-Type", csv.ExportContentType(export.Format))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.FileName}))
+	c.Status(http.StatusOK)
+
+	if err := h.participantUsecase.WriteExport(c.Request.Context(), export, c.Writer); err != nil {
+		log.Print("error:", err.Error())
+
+		// XLSX baru ditulis setelah semua row terbaca, jadi error masih bisa dikirim sebagai JSON
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			validator.InternalServerErrorResponse(c, "Failed to export participants")
+		}
+	}
+}
+
+func (h *EventHandler) CreateParticipant(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	var req domain.CreateParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	participant, err := h.participantUsecase.CreateParticipant(c.Request.Context(), organizerID, eventID, &req)
+	if err != nil {
+		if quotaExceededResponse(c, err) {
+			return
+		}
+
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.CreatedResponse(c, "Participant created successfully", participant)
+}
+
+func (h *EventHandler) UpdateParticipant(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
 		return
 	}
 
-	validator.SuccessResponse(c, "Participants retrieve successfully", participants)
+	// Dapatkan parameter event ID dan participant ID dari URL
+	eventID := c.Param("eventID")
+	participantID, err := strconv.ParseInt(c.Param("participantID"), 10, 64)
+	if err != nil {
+		validator.BadRequestResponse(c, "Invalid participant ID")
+		return
+	}
+
+	var req domain.UpdateParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	participant, err := h.participantUsecase.UpdateParticipant(c.Request.Context(), organizerID, eventID, participantID, &req)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participant updated successfully", participant)
+}
+
+func (h *EventHandler) DeleteParticipant(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dan participant ID dari URL
+	eventID := c.Param("eventID")
+	participantID, err := strconv.ParseInt(c.Param("participantID"), 10, 64)
+	if err != nil {
+		validator.BadRequestResponse(c, "Invalid participant ID")
+		return
+	}
+
+	err = h.participantUsecase.DeleteParticipant(c.Request.Context(), organizerID, eventID, participantID)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participant deleted successfully", nil)
+}
+
+func (h *EventHandler) BuyCapacity(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	var req domain.BuyCapacityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	response, err := h.eventUsecase.BuyCapacity(c.Request.Context(), organizerID, eventID, &req)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Participant capacity added successfully", response)
+}
+
+func (h *EventHandler) GetImportMapping(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	mapping, err := h.participantUsecase.GetImportMapping(c.Request.Context(), organizerID)
+	if err != nil {
+		log.Print("error:", err.Error())
+		validator.InternalServerErrorResponse(c, "Failed to get import mapping")
+		return
+	}
+
+	validator.SuccessResponse(c, "Import mapping retrieved successfully", mapping)
+}
+
+func (h *EventHandler) SaveImportMapping(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	var req domain.ImportMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	mapping, err := h.participantUsecase.SaveImportMapping(c.Request.Context(), organizerID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidColumnMapping) {
+			validator.BadRequestResponse(c, err.Error())
+			return
+		}
+
+		log.Print("error:", err.Error())
+		validator.InternalServerErrorResponse(c, "Failed to save import mapping")
+		return
+	}
+
+	validator.SuccessResponse(c, "Import mapping saved successfully", mapping)
+}
+
+func (h *EventHandler) GetAttributeSchema(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	eventID := c.Param("eventID")
+
+	schema, err := h.eventUsecase.GetAttributeSchema(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Attribute schema retrieved successfully", schema)
+}
+
+func (h *EventHandler) SaveAttributeSchema(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	eventID := c.Param("eventID")
+
+	var req domain.AttributeSchemaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	schema, err := h.eventUsecase.UpdateAttributeSchema(c.Request.Context(), organizerID, eventID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAttributeSchema) {
+			validator.BadRequestResponse(c, err.Error())
+			return
+		}
+
+		log.Print("error:", err.Error())
+		statusCode, message := h.handleParticipantError(err)
+		validator.ErrorResponse(c, statusCode, message)
+		return
+	}
+
+	validator.SuccessResponse(c, "Attribute schema saved successfully", schema)
+}
+
+// importFileFormat menentukan format file import dari ekstensi, lalu dari Content-Type
+// Browser sering mengirim Content-Type yang berbeda untuk CSV, misalnya application/vnd.ms-excel
+// atau text/plain, sehingga ekstensi file lebih diutamakan
+func importFileFormat(file *multipart.FileHeader) (string, bool) {
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".xlsx":
+		return domain.ImportFormatXLSX, true
+	case ".csv":
+		return domain.ImportFormatCSV, true
+	case ".xls":
+		// Format Excel lama tidak didukung walaupun Content-Type-nya sama dengan CSV
+		return "", false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(file.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		return domain.ImportFormatXLSX, true
+	case "text/csv", "application/csv", "text/plain", "application/vnd.ms-excel":
+		return domain.ImportFormatCSV, true
+	default:
+		return "", false
+	}
+}
+
+// isImportRequestError return true jika import gagal karena file atau opsi import dari organizer
+func isImportRequestError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidImportMode) ||
+		errors.Is(err, domain.ErrInvalidColumnMapping) ||
+		errors.Is(err, domain.ErrMissingColumn) ||
+		errors.Is(err, domain.ErrEmptyCSV) ||
+		errors.Is(err, domain.ErrInvalidCSVFormat) ||
+		errors.Is(err, domain.ErrUnsupportedCharset) ||
+		errors.Is(err, domain.ErrInvalidXLSXFormat) ||
+		errors.Is(err, domain.ErrEmptySheet) ||
+		errors.Is(err, domain.ErrSheetNotFound) ||
+		errors.Is(err, domain.ErrUnsupportedImportFormat) ||
+		errors.Is(err, domain.ErrImportFileTooLarge)
+}
+
+// quotaExceededResponse mengirim 409 beserta detail kuota jika err adalah error kuota participant
+// Return true jika response sudah dikirim
+func quotaExceededResponse(c *gin.Context, err error) bool {
+	var quotaErr *domain.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+
+	validator.ConflictResponse(c, quotaErr.Error(), quotaErr)
+	return true
+}
+
+func (h *EventHandler) handleParticipantError(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrEventNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, "participant not found"
+	case errors.Is(err, domain.ErrUnauthorizedAccess):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, domain.ErrParticipantAlreadyExists):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrParticipantNameRequired),
+		errors.Is(err, domain.ErrParticipantEmailRequired),
+		errors.Is(err, domain.ErrParticipantPhoneRequired),
+		errors.Is(err, domain.ErrInvalidPhone),
+		errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrAttributeRequired),
+		errors.Is(err, domain.ErrInvalidAttribute):
+		return http.StatusBadRequest, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "Internal server error"
+	}
 }
@@ -2,8 +2,10 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
+	"github.com/fzndps/eventcheck/internal/domain"
 	"github.com/fzndps/eventcheck/pkg/jwt"
 	"github.com/fzndps/eventcheck/pkg/validator"
 	"github.com/gin-gonic/gin"
@@ -22,30 +24,22 @@ func NewAuthMiddleware(jwtManager *jwt.JWTManager) *AuthMiddleware {
 func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 	// Return function akan dijalankan saat ada request
 	return func(c *gin.Context) {
-		// authHeader ini bisasanya berbentuk : Bearer <token>
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			validator.UnauthorizedResponse(c, "Authorization header required")
+		tokenString, ok := bearerToken(c)
+		if !ok {
 			c.Abort()
 			return
 		}
 
-		// Split berfungsi untuk mengubah "Bearer <token>" menjadi ["Bearer", "<token>"]
-		parts := strings.Split(authHeader, " ")
-
-		// Validasi format harus ada 2 parts dan parts pertama harus berisi "Bearer"
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			validator.UnauthorizedResponse(c, "Invalid authorization format.")
-			c.Abort()
-			return
-		}
-
-		// Parts ke 2 adalah token
-		tokenString := parts[1]
-
 		// ValidateJWT dari validator akan mengecek token masih valid dan belum expired
 		claims, err := m.jwtManager.ValidateToken(tokenString)
 		if err != nil {
+			// Token scanner valid tapi tidak boleh mengakses route organizer
+			if errors.Is(err, jwt.ErrScannerTokenNotAllowed) {
+				validator.ForbiddenResponse(c, err.Error())
+				c.Abort()
+				return
+			}
+
 			validator.UnauthorizedResponse(c, err.Error())
 			c.Abort()
 			return
@@ -61,6 +55,77 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 	}
 }
 
+// ScannerAccess dipakai untuk route scanner (check-in dan lookup)
+// Route ini bisa diakses organizer atau scanner session, tapi token scanner
+// hanya berlaku untuk event yang ada di parameter :eventID
+func (m *AuthMiddleware) ScannerAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, ok := bearerToken(c)
+		if !ok {
+			c.Abort()
+			return
+		}
+
+		// Coba sebagai token organizer terlebih dahulu
+		claims, err := m.jwtManager.ValidateToken(tokenString)
+		if err == nil {
+			c.Set("organizer_id", claims.OrganizerID)
+			c.Set("organizer_email", claims.Email)
+			c.Next()
+			return
+		}
+
+		if !errors.Is(err, jwt.ErrScannerTokenNotAllowed) {
+			validator.UnauthorizedResponse(c, err.Error())
+			c.Abort()
+			return
+		}
+
+		scannerClaims, err := m.jwtManager.ValidateScannerToken(tokenString)
+		if err != nil {
+			validator.UnauthorizedResponse(c, err.Error())
+			c.Abort()
+			return
+		}
+
+		// Scanner session hanya boleh mengakses event tempat dia login
+		if scannerClaims.EventID != c.Param("eventID") {
+			validator.ForbiddenResponse(c, domain.ErrUnauthorizedAccess.Error())
+			c.Abort()
+			return
+		}
+
+		c.Set("scanner_event_id", scannerClaims.EventID)
+		c.Set("scanner_session_id", scannerClaims.ID)
+		c.Set("scanner_pin_version", scannerClaims.PINVersion)
+
+		c.Next()
+	}
+}
+
+// bearerToken mengambil token dari header Authorization
+// Jika gagal, response error sudah dikirim ke client
+func bearerToken(c *gin.Context) (string, bool) {
+	// authHeader ini bisasanya berbentuk : Bearer <token>
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		validator.UnauthorizedResponse(c, "Authorization header required")
+		return "", false
+	}
+
+	// Split berfungsi untuk mengubah "Bearer <token>" menjadi ["Bearer", "<token>"]
+	parts := strings.Split(authHeader, " ")
+
+	// Validasi format harus ada 2 parts dan parts pertama harus berisi "Bearer"
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		validator.UnauthorizedResponse(c, "Invalid authorization format.")
+		return "", false
+	}
+
+	// Parts ke 2 adalah token
+	return parts[1], true
+}
+
 // GetUserID untuk mengambil userID
 func GetOrganizerID(c *gin.Context) (int64, bool) {
 	// Ambil data dari context
@@ -91,3 +156,17 @@ func GetEmail(c *gin.Context) (string, bool) {
 
 	return emailSTR, true
 }
+
+// GetActor mengambil actor yang sedang login, organizer atau scanner session
+func GetActor(c *gin.Context) (*domain.Actor, bool) {
+	if organizerID, ok := GetOrganizerID(c); ok {
+		return domain.NewOrganizerActor(organizerID), true
+	}
+
+	eventID := c.GetString("scanner_event_id")
+	if eventID == "" {
+		return nil, false
+	}
+
+	return domain.NewScannerActor(eventID, c.GetString("scanner_session_id"), c.GetInt("scanner_pin_version")), true
+}
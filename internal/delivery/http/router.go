@@ -11,6 +11,8 @@ type RouterConfig struct {
 	AuthHandler    *AuthHandler
 	EventHandler   *EventHandler
 	QREmailHandler *QREmailHandler
+	CheckInHandler *CheckInHandler
+	ScannerHandler *ScannerHandler
 	AuthMiddleware *middleware.AuthMiddleware
 }
 
@@ -34,6 +36,8 @@ func SetupRouter(cfg *RouterConfig) *gin.Engine {
 			auth.POST("/login", cfg.AuthHandler.Login)
 
 			auth.GET("/profile", cfg.AuthMiddleware.AuthRequired(), cfg.AuthHandler.GetProfile)
+			auth.GET("/profile/import-mapping", cfg.AuthMiddleware.AuthRequired(), cfg.EventHandler.GetImportMapping)
+			auth.PUT("/profile/import-mapping", cfg.AuthMiddleware.AuthRequired(), cfg.EventHandler.SaveImportMapping)
 		}
 
 		events := v1.Group("/events")
@@ -44,12 +48,49 @@ func SetupRouter(cfg *RouterConfig) *gin.Engine {
 			events.GET("/:eventID", cfg.EventHandler.GetEventDetail)
 			events.PUT("/:eventID", cfg.EventHandler.UpdateEvent)
 			events.DELETE("/:eventID", cfg.EventHandler.DeleteEvent)
+			events.POST("/:eventID/capacity", cfg.EventHandler.BuyCapacity)
+			events.GET("/:eventID/attributes", cfg.EventHandler.GetAttributeSchema)
+			events.PUT("/:eventID/attributes", cfg.EventHandler.SaveAttributeSchema)
 			events.POST("/:eventID/participants/upload", cfg.EventHandler.UploadParticipants)
 			events.GET("/:eventID/participants", cfg.EventHandler.ListParticipant)
+			events.GET("/:eventID/participants/export", cfg.EventHandler.ExportParticipants)
+			events.POST("/:eventID/participants", cfg.EventHandler.CreateParticipant)
+			events.PUT("/:eventID/participants/:participantID", cfg.EventHandler.UpdateParticipant)
+			events.DELETE("/:eventID/participants/:participantID", cfg.EventHandler.DeleteParticipant)
 
 			events.POST("/:eventID/send-qr", cfg.QREmailHandler.SendQRCodes)
 			events.POST("/:eventID/participants/:participantID/resend-qr", cfg.QREmailHandler.ResendQRCode)
 
+			events.POST("/:eventID/scanner-pin/rotate", cfg.ScannerHandler.RotatePIN)
+
+			events.GET("/:eventID/live", cfg.CheckInHandler.Live)
+			events.GET("/:eventID/check-in-logs", cfg.CheckInHandler.ListLogs)
+			events.GET("/:eventID/participants/:participantID/check-in-logs", cfg.CheckInHandler.ListParticipantLogs)
+
+		}
+
+		imports := v1.Group("/imports")
+		imports.Use(cfg.AuthMiddleware.AuthRequired())
+		{
+			imports.GET("/:jobID", cfg.EventHandler.GetImportJob)
+		}
+
+		scanner := v1.Group("/scanner")
+		{
+			scanner.POST("/login", cfg.ScannerHandler.Login)
+		}
+
+		// Route scanner bisa diakses organizer atau scanner session event tersebut
+		scannerEvents := v1.Group("/events/:eventID")
+		scannerEvents.Use(cfg.AuthMiddleware.ScannerAccess())
+		{
+			scannerEvents.POST("/check-in", cfg.CheckInHandler.CheckIn)
+			scannerEvents.POST("/check-in/lookup", cfg.CheckInHandler.Lookup)
+			scannerEvents.POST("/check-in/sync", cfg.CheckInHandler.SyncOffline)
+			scannerEvents.GET("/check-in/search", cfg.CheckInHandler.Search)
+			scannerEvents.POST("/check-in/manual", cfg.CheckInHandler.ManualCheckIn)
+			scannerEvents.POST("/check-in/undo", cfg.CheckInHandler.Undo)
+			scannerEvents.POST("/check-out", cfg.CheckInHandler.CheckOut)
 		}
 
 		email := v1.Group("/email")
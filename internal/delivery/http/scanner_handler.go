@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/fzndps/eventcheck/internal/delivery/http/middleware"
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/usecase"
+	"github.com/fzndps/eventcheck/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type ScannerHandler struct {
+	scannerUsecase *usecase.ScannerUsecase
+}
+
+func NewScannerHandler(scannerUsecase *usecase.ScannerUsecase) *ScannerHandler {
+	return &ScannerHandler{
+		scannerUsecase: scannerUsecase,
+	}
+}
+
+// Login menukar slug event dan scanner PIN dengan token scanner session
+func (h *ScannerHandler) Login(c *gin.Context) {
+	var req domain.ScannerLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validator.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	response, err := h.scannerUsecase.Login(c.Request.Context(), &req, c.ClientIP())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidScannerCredentials) {
+			validator.UnauthorizedResponse(c, err.Error())
+			return
+		}
+
+		if errors.Is(err, domain.ErrScannerLocked) {
+			validator.ErrorResponse(c, http.StatusTooManyRequests, err.Error())
+			return
+		}
+
+		log.Print("error:", err.Error())
+		validator.InternalServerErrorResponse(c, "Internal server error")
+		return
+	}
+
+	validator.SuccessResponse(c, "Scanner login successfully", response)
+}
+
+// RotatePIN membuat scanner PIN baru, hanya bisa dilakukan organizer pemilik event
+func (h *ScannerHandler) RotatePIN(c *gin.Context) {
+	// Dapatkan organizer id dari context
+	organizerID, exists := middleware.GetOrganizerID(c)
+	if !exists {
+		validator.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	// Dapatkan parameter event ID dari URL
+	eventID := c.Param("eventID")
+
+	response, err := h.scannerUsecase.RotatePIN(c.Request.Context(), organizerID, eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorizedAccess) {
+			validator.ForbiddenResponse(c, err.Error())
+			return
+		}
+
+		if errors.Is(err, domain.ErrEventNotFound) {
+			validator.NotFoundResponse(c, err.Error())
+			return
+		}
+
+		log.Print("error:", err.Error())
+		validator.InternalServerErrorResponse(c, "Internal server error")
+		return
+	}
+
+	validator.SuccessResponse(c, "Scanner PIN rotated successfully", response)
+}
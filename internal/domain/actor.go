@@ -0,0 +1,40 @@
+package domain
+
+const (
+	ActorTypeOrganizer = "organizer"
+	ActorTypeScanner   = "scanner"
+	ActorTypeSystem    = "system" // Data lama sebelum ada check-in log
+)
+
+// Actor adalah pihak yang melakukan aksi, bisa organizer yang login
+// atau scanner session yang login dengan scanner PIN event
+type Actor struct {
+	Type        string `json:"type"`
+	OrganizerID int64  `json:"organizer_id,omitempty"`
+	EventID     string `json:"event_id,omitempty"`   // Event tempat scanner session berlaku
+	SessionID   string `json:"session_id,omitempty"` // ID scanner session (jti token)
+	PINVersion  int    `json:"-"`                    // Versi scanner PIN saat session dibuat
+}
+
+// NewOrganizerActor membuat actor dari organizer yang login
+func NewOrganizerActor(organizerID int64) *Actor {
+	return &Actor{
+		Type:        ActorTypeOrganizer,
+		OrganizerID: organizerID,
+	}
+}
+
+// NewScannerActor membuat actor dari scanner session
+func NewScannerActor(eventID, sessionID string, pinVersion int) *Actor {
+	return &Actor{
+		Type:       ActorTypeScanner,
+		EventID:    eventID,
+		SessionID:  sessionID,
+		PINVersion: pinVersion,
+	}
+}
+
+// IsScanner return true jika actor adalah scanner session
+func (a *Actor) IsScanner() bool {
+	return a.Type == ActorTypeScanner
+}
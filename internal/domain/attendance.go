@@ -0,0 +1,21 @@
+package domain
+
+// Nama event SSE untuk live attendance stream
+const (
+	LiveEventStats = "stats" // Statistik awal saat dashboard terhubung
+	LiveEventScan  = "scan"  // Setiap scan yang tercatat di check-in log
+)
+
+// AttendanceStats statistik kehadiran event
+type AttendanceStats struct {
+	Registered int `json:"participant_registered"`
+	CheckedIn  int `json:"participant_checked_in"`
+	Inside     int `json:"participant_inside"`
+}
+
+// AttendanceUpdate dikirim ke dashboard setiap ada scan di event
+type AttendanceUpdate struct {
+	Log         *CheckInLog      `json:"log"`
+	Participant *CheckInResponse `json:"participant,omitempty"` // Kosong untuk token yang tidak dikenal
+	Stats       *AttendanceStats `json:"stats"`
+}
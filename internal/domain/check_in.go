@@ -0,0 +1,131 @@
+package domain
+
+import "time"
+
+// CheckInRequest request dari scanner berisi token hasil scan QR
+// beserta device dan gate tempat scan dilakukan
+type CheckInRequest struct {
+	ScanInfo
+
+	// Hanya organizer yang boleh check-in di luar jam check-in event
+	OverrideWindow bool `json:"override_window"`
+}
+
+// ManualCheckInRequest request check-in manual dari hasil pencarian participant
+type ManualCheckInRequest struct {
+	ParticipantID int64  `json:"participant_id" binding:"required,min=1"`
+	DeviceID      string `json:"device_id" binding:"max=100"`
+	Gate          string `json:"gate" binding:"max=100"`
+
+	OverrideWindow bool `json:"override_window"`
+}
+
+// UndoCheckInRequest request membatalkan check-in participant
+type UndoCheckInRequest struct {
+	ParticipantID int64  `json:"participant_id" binding:"required,min=1"`
+	Reason        string `json:"reason" binding:"required,min=3,max=255"`
+	DeviceID      string `json:"device_id" binding:"max=100"`
+	Gate          string `json:"gate" binding:"max=100"`
+}
+
+// ParticipantSearchRequest query pencarian participant berdasarkan nama, email atau phone
+type ParticipantSearchRequest struct {
+	Query string `form:"q" binding:"required,min=2,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// ParticipantSearchResult data participant untuk ditampilkan di hasil pencarian scanner
+// QR token sengaja tidak dikirim
+type ParticipantSearchResult struct {
+	ParticipantID int64      `json:"participant_id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Phone         string     `json:"phone"`
+	CheckedIn     bool       `json:"checked_in"`
+	CheckedInAt   *time.Time `json:"checked_in_at"`
+	IsInside      bool       `json:"is_inside"`
+}
+
+// ParticipantSearchResponse response pencarian participant
+type ParticipantSearchResponse struct {
+	Participants []*ParticipantSearchResult `json:"participants"`
+}
+
+// NewParticipantSearchResult membuat hasil pencarian dari data participant
+func NewParticipantSearchResult(p *Participant) *ParticipantSearchResult {
+	return &ParticipantSearchResult{
+		ParticipantID: p.ID,
+		Name:          p.Name,
+		Email:         p.Email,
+		Phone:         p.Phone,
+		CheckedIn:     p.IsCheckedIn(),
+		CheckedInAt:   p.CheckedInAt,
+		IsInside:      p.IsInside,
+	}
+}
+
+// CheckInResponse status check-in participant untuk ditampilkan di scanner
+type CheckInResponse struct {
+	ParticipantID int64      `json:"participant_id"`
+	Name          string     `json:"name"`
+	CheckedIn     bool       `json:"checked_in"`
+	CheckedInAt   *time.Time `json:"checked_in_at"`
+	IsInside      bool       `json:"is_inside"`
+	EntryCount    int        `json:"entry_count"`
+}
+
+// NewCheckInResponse membuat response check-in dari data participant
+func NewCheckInResponse(p *Participant) *CheckInResponse {
+	return &CheckInResponse{
+		ParticipantID: p.ID,
+		Name:          p.Name,
+		CheckedIn:     p.IsCheckedIn(),
+		CheckedInAt:   p.CheckedInAt,
+		IsInside:      p.IsInside,
+		EntryCount:    p.EntryCount,
+	}
+}
+
+const (
+	SyncResultApplied           = "applied"
+	SyncResultDuplicate         = "duplicate"
+	SyncResultUnknownToken      = "unknown_token"
+	SyncResultWrongEvent        = "wrong_event"
+	SyncResultEntryLimitReached = "entry_limit_reached"
+	SyncResultOutsideWindow     = "outside_check_in_window"
+)
+
+// OfflineCheckInRecord satu check-in yang dicatat scanner saat offline
+type OfflineCheckInRecord struct {
+	QRToken   string    `json:"qr_token" binding:"required"`
+	ScannedAt time.Time `json:"scanned_at" binding:"required"`
+	DeviceID  string    `json:"device_id" binding:"required,max=100"`
+	Gate      string    `json:"gate" binding:"max=100"`
+}
+
+// OfflineCheckInBatchRequest request upload check-in offline dari scanner
+type OfflineCheckInBatchRequest struct {
+	Records []*OfflineCheckInRecord `json:"records" binding:"required,min=1,max=500,dive"`
+}
+
+// OfflineCheckInResult hasil sync untuk satu record
+type OfflineCheckInResult struct {
+	Index         int        `json:"index"` // Urutan record di request
+	QRToken       string     `json:"qr_token"`
+	DeviceID      string     `json:"device_id"`
+	Result        string     `json:"result"`
+	ParticipantID int64      `json:"participant_id,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	CheckedInAt   *time.Time `json:"checked_in_at,omitempty"`
+}
+
+// OfflineCheckInBatchResponse response sync check-in offline
+type OfflineCheckInBatchResponse struct {
+	Applied           int                     `json:"applied"`
+	Duplicate         int                     `json:"duplicate"`
+	UnknownToken      int                     `json:"unknown_token"`
+	WrongEvent        int                     `json:"wrong_event"`
+	EntryLimitReached int                     `json:"entry_limit_reached"`
+	OutsideWindow     int                     `json:"outside_check_in_window"`
+	Results           []*OfflineCheckInResult `json:"results"`
+}
@@ -0,0 +1,126 @@
+package domain
+
+import "time"
+
+const (
+	CheckInActionCheckIn  = "check_in"
+	CheckInActionCheckOut = "check_out"
+	CheckInActionUndo     = "undo" // Membatalkan semua entry dan exit participant
+)
+
+const (
+	CheckInMethodQR     = "qr"
+	CheckInMethodManual = "manual" // Dicari dan di check-in manual oleh petugas
+)
+
+const (
+	CheckInResultAccepted          = "accepted"
+	CheckInResultDuplicate         = "duplicate" // Participant sudah berada di dalam
+	CheckInResultUnknownToken      = "unknown_token"
+	CheckInResultWrongEvent        = "wrong_event"
+	CheckInResultNotInside         = "not_inside"          // Check-out saat participant tidak di dalam
+	CheckInResultEntryLimitReached = "entry_limit_reached" // Re-entry melebihi policy event
+	CheckInResultNotCheckedIn      = "not_checked_in"      // Undo saat participant belum check-in
+	CheckInResultNotOpen           = "check_in_not_open"   // Scan sebelum jam check-in dibuka
+	CheckInResultClosed            = "check_in_closed"     // Scan setelah jam check-in ditutup
+)
+
+// CheckInLog mencatat setiap percobaan scan, berhasil maupun gagal
+// Status check-in di tabel participants selalu dihitung ulang dari log ini
+type CheckInLog struct {
+	ID               int64     `json:"id"`
+	EventID          string    `json:"event_id"`
+	ParticipantID    *int64    `json:"participant_id"`
+	QRToken          string    `json:"qr_token"`
+	Action           string    `json:"action"`
+	Method           string    `json:"method"`
+	Result           string    `json:"result"`
+	ActorType        string    `json:"actor_type"`
+	ActorOrganizerID *int64    `json:"actor_organizer_id,omitempty"`
+	ActorSessionID   string    `json:"actor_session_id,omitempty"`
+	DeviceID         string    `json:"device_id,omitempty"`
+	Gate             string    `json:"gate,omitempty"`
+	ScannedAt        time.Time `json:"scanned_at"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	// Diisi untuk log undo
+	Reason string `json:"reason,omitempty"`
+
+	// True jika organizer melewati batas jam check-in untuk scan ini
+	WindowOverride bool `json:"window_override,omitempty"`
+
+	// Diisi jika log ini dibatalkan oleh undo, log tetap disimpan sebagai history
+	RevertedAt *time.Time `json:"reverted_at,omitempty"`
+	RevertedBy *int64     `json:"reverted_by,omitempty"` // ID log undo
+}
+
+// NewCheckInLog membuat log scan untuk actor tertentu
+func NewCheckInLog(actor *Actor, eventID, action string, scan *ScanInfo, scannedAt time.Time) *CheckInLog {
+	log := &CheckInLog{
+		EventID:        eventID,
+		QRToken:        scan.QRToken,
+		Action:         action,
+		Method:         CheckInMethodQR,
+		ActorType:      actor.Type,
+		ActorSessionID: actor.SessionID,
+		DeviceID:       scan.DeviceID,
+		Gate:           scan.Gate,
+		ScannedAt:      scannedAt,
+	}
+
+	if actor.Type == ActorTypeOrganizer {
+		organizerID := actor.OrganizerID
+		log.ActorOrganizerID = &organizerID
+	}
+
+	return log
+}
+
+// ParticipantPresence status keberadaan participant saat scan diproses
+type ParticipantPresence struct {
+	IsInside   bool
+	EntryCount int
+}
+
+// ResolveScanResult menentukan result scan yang valid berdasarkan status participant
+// maxEntries 0 berarti participant boleh masuk tanpa batas
+func ResolveScanResult(action string, presence ParticipantPresence, maxEntries int) string {
+	switch action {
+	case CheckInActionCheckOut:
+		if !presence.IsInside {
+			return CheckInResultNotInside
+		}
+		return CheckInResultAccepted
+	case CheckInActionUndo:
+		if presence.EntryCount == 0 {
+			return CheckInResultNotCheckedIn
+		}
+		return CheckInResultAccepted
+	}
+
+	if presence.IsInside {
+		return CheckInResultDuplicate
+	}
+
+	if maxEntries > 0 && presence.EntryCount >= maxEntries {
+		return CheckInResultEntryLimitReached
+	}
+
+	return CheckInResultAccepted
+}
+
+// ScanInfo data yang dikirim scanner untuk setiap scan
+type ScanInfo struct {
+	QRToken  string `json:"qr_token" binding:"required"`
+	DeviceID string `json:"device_id" binding:"max=100"`
+	Gate     string `json:"gate" binding:"max=100"`
+}
+
+// CheckInLogListResponse response list check-in log dengan pagination
+type CheckInLogListResponse struct {
+	Logs      []*CheckInLog `json:"logs"`
+	Total     int           `json:"total"`
+	Page      int           `json:"page"`
+	Limit     int           `json:"limit"`
+	TotalPage int           `json:"total_page"`
+}
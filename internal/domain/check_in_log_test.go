@@ -0,0 +1,122 @@
+package domain
+
+import "testing"
+
+func TestResolveScanResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     string
+		presence   ParticipantPresence
+		maxEntries int
+		expected   string
+	}{
+		{
+			name:       "First entry",
+			action:     CheckInActionCheckIn,
+			presence:   ParticipantPresence{},
+			maxEntries: 1,
+			expected:   CheckInResultAccepted,
+		},
+		{
+			name:       "Scan while inside",
+			action:     CheckInActionCheckIn,
+			presence:   ParticipantPresence{IsInside: true, EntryCount: 1},
+			maxEntries: 0,
+			expected:   CheckInResultDuplicate,
+		},
+		{
+			name:       "Single entry after check-out",
+			action:     CheckInActionCheckIn,
+			presence:   ParticipantPresence{EntryCount: 1},
+			maxEntries: 1,
+			expected:   CheckInResultEntryLimitReached,
+		},
+		{
+			name:       "Limited re-entry below limit",
+			action:     CheckInActionCheckIn,
+			presence:   ParticipantPresence{EntryCount: 2},
+			maxEntries: 3,
+			expected:   CheckInResultAccepted,
+		},
+		{
+			name:       "Unlimited re-entry",
+			action:     CheckInActionCheckIn,
+			presence:   ParticipantPresence{EntryCount: 50},
+			maxEntries: 0,
+			expected:   CheckInResultAccepted,
+		},
+		{
+			name:       "Check-out while inside",
+			action:     CheckInActionCheckOut,
+			presence:   ParticipantPresence{IsInside: true, EntryCount: 1},
+			maxEntries: 1,
+			expected:   CheckInResultAccepted,
+		},
+		{
+			name:       "Check-out while outside",
+			action:     CheckInActionCheckOut,
+			presence:   ParticipantPresence{EntryCount: 1},
+			maxEntries: 1,
+			expected:   CheckInResultNotInside,
+		},
+		{
+			name:       "Undo after check-out",
+			action:     CheckInActionUndo,
+			presence:   ParticipantPresence{EntryCount: 1},
+			maxEntries: 1,
+			expected:   CheckInResultAccepted,
+		},
+		{
+			name:       "Undo before check-in",
+			action:     CheckInActionUndo,
+			presence:   ParticipantPresence{},
+			maxEntries: 1,
+			expected:   CheckInResultNotCheckedIn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResolveScanResult(tt.action, tt.presence, tt.maxEntries)
+
+			if result != tt.expected {
+				t.Errorf("ResolveScanResult() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvent_EntryLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    *Event
+		expected int
+	}{
+		{"Single", &Event{ReentryPolicy: ReentryPolicySingle, MaxEntries: 5}, 1},
+		{"Unlimited", &Event{ReentryPolicy: ReentryPolicyUnlimited}, 0},
+		{"Limited", &Event{ReentryPolicy: ReentryPolicyLimited, MaxEntries: 3}, 3},
+		{"Empty policy defaults to single", &Event{}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if limit := tt.event.EntryLimit(); limit != tt.expected {
+				t.Errorf("EntryLimit() = %d, expected %d", limit, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateReentryPolicy(t *testing.T) {
+	if err := ValidateReentryPolicy(ReentryPolicyLimited, 0); err != ErrInvalidReentryPolicy {
+		t.Errorf("Expected ErrInvalidReentryPolicy for limited without max_entries, got %v", err)
+	}
+
+	if err := ValidateReentryPolicy(ReentryPolicyLimited, 2); err != nil {
+		t.Errorf("Expected nil for limited with max_entries, got %v", err)
+	}
+
+	if err := ValidateReentryPolicy("sometimes", 0); err != ErrInvalidReentryPolicy {
+		t.Errorf("Expected ErrInvalidReentryPolicy for unknown policy, got %v", err)
+	}
+}
@@ -7,8 +7,25 @@ var (
 	ErrParticipantNameRequired  = errors.New("participant name is required")
 	ErrParticipantEmailRequired = errors.New("participant email is required")
 	ErrParticipantPhoneRequired = errors.New("participant phone number is required")
+	ErrInvalidPhone             = errors.New("participant phone number is invalid")
+	ErrInvalidEmail             = errors.New("participant email is invalid")
+	ErrParticipantAlreadyExists = errors.New("participant with the same email or phone number is already registered")
 	ErrInvalidCSVFormat         = errors.New("invalid CSV format")
 	ErrEmptyCSV                 = errors.New("CSV file is empty")
+	ErrUnsupportedCharset       = errors.New("unsupported CSV charset")
+	ErrInvalidXLSXFormat        = errors.New("invalid XLSX format")
+	ErrEmptySheet               = errors.New("sheet is empty")
+	ErrSheetNotFound            = errors.New("sheet not found in XLSX file")
+	ErrUnsupportedImportFormat  = errors.New("file must be in CSV or XLSX format")
+	ErrInvalidImportMode        = errors.New("invalid import mode (use reject, skip or update)")
+	ErrParticipantQuotaExceeded = errors.New("participant quota exceeded")
+	ErrMissingColumn            = errors.New("required column not found in CSV")
+	ErrInvalidColumnMapping     = errors.New("invalid column mapping")
+	ErrImportTokenNotFound      = errors.New("import token not found or expired")
+	ErrImportFileTooLarge       = errors.New("import file is too large (maximum 15 MB)")
+	ErrImportJobNotRunning      = errors.New("import job is no longer running")
+	ErrAttributeRequired        = errors.New("participant attribute is required")
+	ErrInvalidAttribute         = errors.New("participant attribute is invalid")
 
 	// Event errors
 	ErrEventNotFound      = errors.New("event not found")
@@ -16,6 +33,27 @@ var (
 	ErrSlugAlreadyExists  = errors.New("event slug already in use")
 	ErrUnauthorizedAccess = errors.New("you do not have access to this event")
 
+	ErrInvalidReentryPolicy   = errors.New("invalid re-entry policy (limited requires max_entries)")
+	ErrInvalidAttributeSchema = errors.New("invalid attribute schema")
+
+	ErrPendingCapacityNotFound = errors.New("no pending capacity purchase matches the paid amount")
+
+	// Check-in errors
+	ErrInvalidQRToken          = errors.New("QR token is not recognized")
+	ErrParticipantWrongEvent   = errors.New("QR token belongs to another event")
+	ErrParticipantCheckedIn    = errors.New("participant already checked in")
+	ErrParticipantNotInside    = errors.New("participant is not inside the venue")
+	ErrEntryLimitReached       = errors.New("participant has reached the maximum number of entries")
+	ErrParticipantNotCheckedIn = errors.New("participant has not checked in")
+	ErrCheckInNotOpen          = errors.New("check-in for this event is not open yet")
+	ErrCheckInClosed           = errors.New("check-in for this event is already closed")
+	ErrWindowOverrideForbidden = errors.New("only the organizer can override the check-in window")
+
+	// Scanner errors
+	ErrInvalidScannerCredentials = errors.New("invalid event slug or scanner PIN")
+	ErrScannerLocked             = errors.New("too many failed PIN attempts, try again later")
+	ErrScannerSessionRevoked     = errors.New("scanner session is no longer valid, please login again")
+
 	//General errors
 	ErrNotFound       = errors.New("data tidak ditemukan")
 	ErrInternalServer = errors.New("terjadi kesalahan server")
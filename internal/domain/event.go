@@ -8,18 +8,37 @@ import (
 
 // Entity event
 type Event struct {
-	ID               string    `json:"id"` // UUID format
-	OrganizerID      int64     `json:"organizer_id"`
-	Name             string    `json:"name"`
-	Slug             string    `json:"slug"` // URL-friendly name
-	Date             time.Time `json:"date"`
-	Venue            string    `json:"venue"`
-	ParticipantCount int       `json:"participant_count"`
-	TotalPrice       int       `json:"total_price"`
-	PaymentStatus    string    `json:"payment_status"`
-	PaymentProofURL  string    `json:"payment_proof_url"`
-	ScannerPIN       string    `json:"scanner_pin"`
-	CreatedAt        time.Time `json:"created_at"`
+	ID                string    `json:"id"` // UUID format
+	OrganizerID       int64     `json:"organizer_id"`
+	Name              string    `json:"name"`
+	Slug              string    `json:"slug"` // URL-friendly name
+	Date              time.Time `json:"date"`
+	Venue             string    `json:"venue"`
+	ParticipantCount  int       `json:"participant_count"` // Kuota yang sudah dibayar
+	TotalPrice        int       `json:"total_price"`
+	PaymentStatus     string    `json:"payment_status"`
+	PaymentProofURL   string    `json:"payment_proof_url"`
+	ScannerPINHash    string    `json:"-"` // bcrypt hash dari scanner PIN
+	ScannerPINVersion int       `json:"-"` // Naik setiap PIN di rotate
+	ReentryPolicy     string    `json:"reentry_policy"`
+	MaxEntries        int       `json:"max_entries"`                 // Hanya dipakai untuk policy limited
+	TicketPublicKey   string    `json:"ticket_public_key,omitempty"` // Ed25519 public key untuk verifikasi QR offline
+	TicketPrivateKey  string    `json:"-"`
+
+	// Jam buka dan tutup check-in relatif terhadap Date, nil berarti tidak dibatasi
+	CheckInOpensBefore *int      `json:"check_in_opens_before_minutes"`
+	CheckInClosesAfter *int      `json:"check_in_closes_after_minutes"`
+	CreatedAt          time.Time `json:"created_at"`
+
+	// Kuota tambahan yang menunggu verifikasi pembayaran beserta selisih harganya
+	PendingParticipants int `json:"pending_participants"`
+	PendingPrice        int `json:"pending_price"`
+
+	// Attribute tambahan participant yang didefinisikan organizer
+	AttributeSchema AttributeSchema `json:"attribute_schema,omitempty"`
+
+	// Scanner PIN plain text, hanya diisi saat event dibuat atau PIN di rotate
+	ScannerPIN string `json:"scanner_pin,omitempty"`
 
 	// Relationships (untuk response, tidak disimpan di DB)
 	Organizer    *Organizer     `json:"organizer,omitempty"`
@@ -32,12 +51,23 @@ const (
 	PaymentStatusActive   = "active"
 )
 
+const (
+	ReentryPolicySingle    = "single"    // Hanya boleh masuk sekali
+	ReentryPolicyUnlimited = "unlimited" // Boleh keluar masuk tanpa batas
+	ReentryPolicyLimited   = "limited"   // Boleh masuk maksimal MaxEntries kali
+)
+
 // DTO create event
 type CreateEventRequest struct {
 	Name             string     `json:"name" binding:"required,min=3,max=255"`
 	Date             CustomDate `json:"date" binding:"required"`
 	Venue            string     `json:"venue" binding:"required,min=5,max=500"`
 	ParticipantCount int        `json:"participant_count" binding:"required,min=1"`
+	ReentryPolicy    string     `json:"reentry_policy" binding:"omitempty,oneof=single unlimited limited"`
+	MaxEntries       int        `json:"max_entries" binding:"omitempty,min=1"`
+
+	CheckInOpensBefore *int `json:"check_in_opens_before_minutes" binding:"omitempty,min=0"`
+	CheckInClosesAfter *int `json:"check_in_closes_after_minutes" binding:"omitempty,min=0"`
 }
 
 // DTO update event
@@ -45,6 +75,15 @@ type UpdateEventRequest struct {
 	Name  string      `json:"name" binding:"omitempty,required,min=3,max=255"`
 	Date  *CustomDate `json:"date" binding:"required"`
 	Venue string      `json:"venue" binding:"omitempty,required,min=5,max=500"`
+
+	ReentryPolicy string `json:"reentry_policy" binding:"omitempty,oneof=single unlimited limited"`
+	MaxEntries    *int   `json:"max_entries" binding:"omitempty,min=1"`
+
+	CheckInOpensBefore *int `json:"check_in_opens_before_minutes" binding:"omitempty,min=0"`
+	CheckInClosesAfter *int `json:"check_in_closes_after_minutes" binding:"omitempty,min=0"`
+
+	// Menghapus kedua batas jam check-in, batas yang dikirim bersamaan tetap disimpan
+	ClearCheckInWindow bool `json:"clear_check_in_window"`
 }
 
 // Response list event
@@ -57,11 +96,16 @@ type EventListResponse struct {
 }
 
 // Response detail event dengan partisipan
+// Participants hanya berisi participant terbaru, list lengkap ada di ParticipantsURL
 type EventDetailResponse struct {
 	Event                 *Event         `json:"event"`
 	Participants          []*Participant `json:"participants"`
+	ParticipantsLimit     int            `json:"participants_limit"`
+	HasMoreParticipants   bool           `json:"has_more_participants"`
+	ParticipantsURL       string         `json:"participants_url"` // List participant dengan pagination
 	ParticipantRegistered int            `json:"participant_registered"`
 	ParticipantCheckedIn  int            `json:"participant_checked_in"`
+	ParticipantInside     int            `json:"participant_inside"` // Occupancy saat ini
 }
 
 // Melakukan validasi untuk event
@@ -71,7 +115,79 @@ func (r *CreateEventRequest) Validate() error {
 		return ErrInvalidEventDate
 	}
 
-	return nil
+	if r.ReentryPolicy == "" {
+		r.ReentryPolicy = ReentryPolicySingle
+	}
+
+	return ValidateReentryPolicy(r.ReentryPolicy, r.MaxEntries)
+}
+
+// ValidateReentryPolicy memastikan policy limited selalu punya batas entry
+func ValidateReentryPolicy(policy string, maxEntries int) error {
+	switch policy {
+	case ReentryPolicySingle, ReentryPolicyUnlimited:
+		return nil
+	case ReentryPolicyLimited:
+		if maxEntries < 1 {
+			return ErrInvalidReentryPolicy
+		}
+		return nil
+	default:
+		return ErrInvalidReentryPolicy
+	}
+}
+
+// HasTicketKey return true jika QR participant memakai payload signed
+// Event lama tanpa key tetap memakai QR token hex
+func (e *Event) HasTicketKey() bool {
+	return e.TicketPublicKey != "" && e.TicketPrivateKey != ""
+}
+
+// CheckInOpensAt waktu check-in mulai dibuka, nil jika tidak dibatasi
+func (e *Event) CheckInOpensAt() *time.Time {
+	if e.CheckInOpensBefore == nil {
+		return nil
+	}
+
+	opensAt := e.Date.Add(-time.Duration(*e.CheckInOpensBefore) * time.Minute)
+	return &opensAt
+}
+
+// CheckInClosesAt waktu check-in ditutup, nil jika tidak dibatasi
+func (e *Event) CheckInClosesAt() *time.Time {
+	if e.CheckInClosesAfter == nil {
+		return nil
+	}
+
+	closesAt := e.Date.Add(time.Duration(*e.CheckInClosesAfter) * time.Minute)
+	return &closesAt
+}
+
+// CheckInWindowResult mengecek apakah check-in pada waktu at masih di dalam jam check-in
+// Return CheckInResultAccepted, CheckInResultNotOpen atau CheckInResultClosed
+func (e *Event) CheckInWindowResult(at time.Time) string {
+	if opensAt := e.CheckInOpensAt(); opensAt != nil && at.Before(*opensAt) {
+		return CheckInResultNotOpen
+	}
+
+	if closesAt := e.CheckInClosesAt(); closesAt != nil && at.After(*closesAt) {
+		return CheckInResultClosed
+	}
+
+	return CheckInResultAccepted
+}
+
+// EntryLimit mengembalikan jumlah maksimal participant boleh masuk
+// 0 berarti tidak dibatasi
+func (e *Event) EntryLimit() int {
+	switch e.ReentryPolicy {
+	case ReentryPolicyUnlimited:
+		return 0
+	case ReentryPolicyLimited:
+		return e.MaxEntries
+	default:
+		return 1
+	}
 }
 
 // CustomDate adalah wrapper untuk time.Time
@@ -3,6 +3,7 @@ package domain
 import (
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestCalculatePrice(t *testing.T) {
@@ -146,3 +147,46 @@ func BenchmarkCalculatePrice(b *testing.B) {
 		CalculatePrice(100)
 	}
 }
+
+func TestEvent_CheckInWindowResult(t *testing.T) {
+	opensBefore := 120 // 2 jam sebelum event
+	closesAfter := 240 // 4 jam setelah event
+
+	event := &Event{
+		Date:               time.Date(2026, 12, 20, 19, 0, 0, 0, time.UTC),
+		CheckInOpensBefore: &opensBefore,
+		CheckInClosesAfter: &closesAfter,
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected string
+	}{
+		{"Before doors open", event.Date.Add(-3 * time.Hour), CheckInResultNotOpen},
+		{"Exactly when doors open", event.Date.Add(-2 * time.Hour), CheckInResultAccepted},
+		{"During event", event.Date.Add(time.Hour), CheckInResultAccepted},
+		{"Exactly when check-in closes", event.Date.Add(4 * time.Hour), CheckInResultAccepted},
+		{"After check-in closes", event.Date.Add(5 * time.Hour), CheckInResultClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := event.CheckInWindowResult(tt.at); result != tt.expected {
+				t.Errorf("CheckInWindowResult() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvent_CheckInWindowResult_NoWindow(t *testing.T) {
+	event := &Event{Date: time.Now()}
+
+	if result := event.CheckInWindowResult(time.Now().AddDate(0, 0, -30)); result != CheckInResultAccepted {
+		t.Errorf("Event without window should accept any time, got %s", result)
+	}
+
+	if event.CheckInOpensAt() != nil || event.CheckInClosesAt() != nil {
+		t.Error("Event without window should not have opens/closes time")
+	}
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Status import job
+const (
+	ImportJobQueued    = "queued"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+const (
+	// ImportJobThreshold ukuran file (byte) di atas batas ini diimport di background
+	ImportJobThreshold = 1 << 20 // 1 MB
+
+	// MaxImportFileSize ukuran maksimal file import (byte)
+	// File disimpan utuh sebagai satu nilai BLOB di import_jobs dan import_previews, jadi harus muat
+	// di MEDIUMBLOB (16 MB) dan di bawah max_allowed_packet default MySQL 8 (64 MB)
+	MaxImportFileSize = 15 << 20 // 15 MB
+
+	// ImportChunkSize jumlah row yang disimpan dalam satu transaction oleh import job
+	ImportChunkSize = 500
+
+	// ImportJobStaleAfter job running yang tidak ada progress selama ini dianggap terhenti,
+	// misalnya karena server restart di tengah import
+	ImportJobStaleAfter = 10 * time.Minute
+)
+
+// ImportJob import participant yang dijalankan di background
+// Setiap chunk disimpan dalam transaction sendiri, chunk yang sudah tersimpan
+// tidak dibatalkan jika chunk berikutnya gagal. Kuota dicek untuk seluruh file sebelum chunk pertama
+type ImportJob struct {
+	ID          string
+	EventID     string
+	OrganizerID int64
+	Status      string
+	Options     *ImportOptions
+
+	// Header file asli, dipakai untuk membuat CSV row yang ditolak
+	Headers []string
+
+	TotalRows     int // perkiraan jumlah row data di file
+	ProcessedRows int
+	Created       int
+	Updated       int
+	Skipped       int
+	Failed        int
+	Errors        []*ImportRowError
+	Warnings      []*ImportRowWarning
+	ErrorMessage  string // alasan job gagal
+
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// IsFinished return true jika job sudah selesai, berhasil maupun gagal
+func (j *ImportJob) IsFinished() bool {
+	return j.Status == ImportJobCompleted || j.Status == ImportJobFailed
+}
+
+// Progress persentase row yang sudah diproses
+// Job yang belum selesai maksimal 99 karena jumlah row hanya perkiraan
+func (j *ImportJob) Progress() int {
+	if j.Status == ImportJobCompleted {
+		return 100
+	}
+
+	if j.TotalRows == 0 {
+		return 0
+	}
+
+	return min(j.ProcessedRows*100/j.TotalRows, 99)
+}
+
+// AddResult menambahkan hasil import satu chunk ke job
+func (j *ImportJob) AddResult(result *ImportResult) {
+	j.Created += result.Created
+	j.Updated += result.Updated
+	j.Skipped += result.Skipped
+}
+
+// AddErrors menambahkan row error ke job
+func (j *ImportJob) AddErrors(rowErrors ...*ImportRowError) {
+	j.Errors = append(j.Errors, rowErrors...)
+	j.Failed += len(rowErrors)
+}
+
+// AddWarnings menambahkan warning untuk row yang tetap diimport
+func (j *ImportJob) AddWarnings(warnings ...*ImportRowWarning) {
+	j.Warnings = append(j.Warnings, warnings...)
+}
+
+// Finish menandai job selesai, err nil berarti semua row sudah diproses
+// Error report diurutkan per row, termasuk untuk job yang gagal di tengah jalan
+func (j *ImportJob) Finish(err error, now time.Time) {
+	j.Status = ImportJobCompleted
+	if err != nil {
+		j.Status = ImportJobFailed
+		j.ErrorMessage = err.Error()
+
+		if saved := j.Created + j.Updated; saved > 0 {
+			j.ErrorMessage += fmt.Sprintf(" (%d participants were already saved before the import stopped)", saved)
+		}
+	}
+
+	sort.SliceStable(j.Errors, func(a, b int) bool {
+		return j.Errors[a].Row < j.Errors[b].Row
+	})
+
+	j.FinishedAt = &now
+}
+
+// IsPartial return true jika job gagal setelah sebagian chunk tersimpan
+func (j *ImportJob) IsPartial() bool {
+	return j.Status == ImportJobFailed && j.Created+j.Updated > 0
+}
+
+// ImportJobResponse status import job untuk organizer
+type ImportJobResponse struct {
+	JobID    string `json:"job_id"`
+	EventID  string `json:"event_id"`
+	Status   string `json:"status"`
+	Mode     string `json:"mode"`
+	Progress int    `json:"progress"`
+
+	TotalRows     int `json:"total_rows"`
+	ProcessedRows int `json:"processed_rows"`
+	Success       int `json:"success"` // created + updated
+	Created       int `json:"created"`
+	Updated       int `json:"updated"`
+	Skipped       int `json:"skipped"`
+	Failed        int `json:"failed"`
+
+	// Job gagal setelah sebagian participant tersimpan, jumlahnya ada di created dan updated
+	Partial bool `json:"partial"`
+
+	// Error dan warning report lengkap hanya dikirim setelah job selesai
+	Errors       []*ImportRowError   `json:"errors"`
+	Warnings     []*ImportRowWarning `json:"warnings"`
+	ErrorMessage string              `json:"error_message,omitempty"`
+
+	// Header file asli, dipakai untuk membuat CSV row yang ditolak
+	Headers []string `json:"-"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// NewImportJobResponse membuat response dari import job
+func NewImportJobResponse(j *ImportJob) *ImportJobResponse {
+	res := &ImportJobResponse{
+		JobID:         j.ID,
+		EventID:       j.EventID,
+		Status:        j.Status,
+		Progress:      j.Progress(),
+		TotalRows:     j.TotalRows,
+		ProcessedRows: j.ProcessedRows,
+		Success:       j.Created + j.Updated,
+		Created:       j.Created,
+		Updated:       j.Updated,
+		Skipped:       j.Skipped,
+		Failed:        j.Failed,
+		Partial:       j.IsPartial(),
+		ErrorMessage:  j.ErrorMessage,
+		CreatedAt:     j.CreatedAt,
+		StartedAt:     j.StartedAt,
+		FinishedAt:    j.FinishedAt,
+	}
+
+	if j.Options != nil {
+		res.Mode = j.Options.Mode
+	}
+
+	if j.IsFinished() {
+		res.Headers = j.Headers
+		res.Errors = j.Errors
+		if res.Errors == nil {
+			res.Errors = []*ImportRowError{}
+		}
+
+		res.Warnings = j.Warnings
+		if res.Warnings == nil {
+			res.Warnings = []*ImportRowWarning{}
+		}
+	}
+
+	return res
+}
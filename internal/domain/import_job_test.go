@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestImportJob_Progress(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      ImportJob
+		expected int
+	}{
+		{"Queued", ImportJob{Status: ImportJobQueued, TotalRows: 1000}, 0},
+		{"Running", ImportJob{Status: ImportJobRunning, TotalRows: 1000, ProcessedRows: 500}, 50},
+		{"Estimate too low", ImportJob{Status: ImportJobRunning, TotalRows: 1000, ProcessedRows: 1200}, 99},
+		{"Unknown total", ImportJob{Status: ImportJobRunning, ProcessedRows: 10}, 0},
+		{"Completed", ImportJob{Status: ImportJobCompleted, TotalRows: 1000, ProcessedRows: 990}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.Progress(); got != tt.expected {
+				t.Errorf("Progress() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestImportJob_Finish(t *testing.T) {
+	now := time.Now()
+
+	job := &ImportJob{Status: ImportJobRunning}
+	job.AddResult(&ImportResult{Created: 3, Updated: 1, Skipped: 2})
+	job.AddResult(&ImportResult{Created: 2})
+	job.AddErrors(&ImportRowError{Row: 4}, &ImportRowError{Row: 9})
+	job.Finish(nil, now)
+
+	if job.Status != ImportJobCompleted || job.FinishedAt == nil || job.ErrorMessage != "" {
+		t.Errorf("Expected completed job, got %+v", job)
+	}
+
+	if job.Created != 5 || job.Updated != 1 || job.Skipped != 2 || job.Failed != 2 {
+		t.Errorf("Unexpected counts: %+v", job)
+	}
+
+	failed := &ImportJob{Status: ImportJobRunning}
+	failed.Finish(errors.New("quota exceeded"), now)
+
+	if failed.Status != ImportJobFailed || failed.ErrorMessage != "quota exceeded" || failed.IsPartial() {
+		t.Errorf("Expected failed job, got %+v", failed)
+	}
+}
+
+func TestImportJob_Finish_Partial(t *testing.T) {
+	job := &ImportJob{Status: ImportJobRunning}
+	job.AddResult(&ImportResult{Created: 500})
+	job.AddErrors(&ImportRowError{Row: 900}, &ImportRowError{Row: 12})
+	job.Finish(errors.New("database unavailable"), time.Now())
+
+	if !job.IsPartial() || !NewImportJobResponse(job).Partial {
+		t.Errorf("Expected partial failed job, got %+v", job)
+	}
+
+	expected := "database unavailable (500 participants were already saved before the import stopped)"
+	if job.ErrorMessage != expected {
+		t.Errorf("Expected error message %q, got %q", expected, job.ErrorMessage)
+	}
+
+	if job.Errors[0].Row != 12 || job.Errors[1].Row != 900 {
+		t.Errorf("Expected errors sorted by row, got rows %d, %d", job.Errors[0].Row, job.Errors[1].Row)
+	}
+}
+
+func TestNewImportJobResponse(t *testing.T) {
+	job := &ImportJob{
+		ID:            "job-1",
+		Status:        ImportJobRunning,
+		Options:       &ImportOptions{Mode: ImportModeSkip},
+		TotalRows:     10,
+		ProcessedRows: 5,
+		Created:       3,
+		Updated:       1,
+		Errors:        []*ImportRowError{{Row: 2}},
+	}
+
+	res := NewImportJobResponse(job)
+	if res.Mode != ImportModeSkip || res.Success != 4 || res.Progress != 50 {
+		t.Errorf("Unexpected response: %+v", res)
+	}
+
+	// Error report hanya dikirim setelah job selesai
+	if res.Errors != nil {
+		t.Errorf("Expected no errors for running job, got %v", res.Errors)
+	}
+
+	job.Finish(nil, time.Now())
+	if res := NewImportJobResponse(job); len(res.Errors) != 1 {
+		t.Errorf("Expected error report for finished job, got %v", res.Errors)
+	}
+
+	job.Errors = nil
+	if res := NewImportJobResponse(job); res.Errors == nil || res.Warnings == nil {
+		t.Error("Expected empty error and warning report instead of null")
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// ImportPreviewTTL lama import token dari dry-run bisa dipakai untuk konfirmasi import
+const ImportPreviewTTL = 30 * time.Minute
+
+// Batas jumlah row yang ditampilkan di preview import
+const (
+	DefaultImportPreviewRows = 20
+	MaxImportPreviewRows     = 100
+)
+
+// ImportPreview file import yang sudah di dry-run dan menunggu konfirmasi organizer
+// File disimpan apa adanya supaya konfirmasi diproses sama persis seperti dry-run
+type ImportPreview struct {
+	Token       string
+	EventID     string
+	OrganizerID int64
+	Options     *ImportOptions
+	Content     []byte
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// IsExpired return true jika import token sudah tidak bisa dipakai
+func (p *ImportPreview) IsExpired(now time.Time) bool {
+	return !now.Before(p.ExpiresAt)
+}
+
+// ImportColumn hasil deteksi kolom file import
+// Field berisi name, email, phone, attribute atau ignore
+type ImportColumn struct {
+	Header    string `json:"header"`
+	Field     string `json:"field"`
+	Attribute string `json:"attribute,omitempty"` // key attribute jika field adalah attribute
+}
+
+// ParticipantFieldAttribute kolom yang disimpan sebagai attribute participant
+const ParticipantFieldAttribute = "attribute"
+
+// ImportPreviewRow participant hasil normalisasi beserta action yang akan dilakukan
+type ImportPreviewRow struct {
+	Row        int               `json:"row"`
+	Action     string            `json:"action"`
+	Name       string            `json:"name"`
+	Email      string            `json:"email"`
+	Phone      string            `json:"phone"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// ImportPreviewResponse hasil dry-run import, tidak ada data yang disimpan
+// ImportToken dipakai untuk menjalankan import tanpa mengupload ulang file
+type ImportPreviewResponse struct {
+	ImportToken string    `json:"import_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Mode        string    `json:"mode"`
+
+	Columns   []*ImportColumn     `json:"columns"`
+	Rows      []*ImportPreviewRow `json:"rows"`
+	TotalRows int                 `json:"total_rows"` // jumlah row data di file
+
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Errors  []*ImportRowError `json:"errors"`
+
+	// Catatan untuk row yang akan diimport, misalnya email yang kemungkinan salah ketik
+	Warnings []*ImportRowWarning `json:"warnings"`
+
+	// Terisi jika participant baru melebihi kuota, import akan ditolak
+	QuotaExceeded *QuotaExceededError `json:"quota_exceeded,omitempty"`
+}
@@ -10,6 +10,11 @@ type Organizer struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// ImportMappingRequest request menyimpan column mapping default untuk import participant
+type ImportMappingRequest struct {
+	Mapping ColumnMapping `json:"mapping" binding:"required"`
+}
+
 type RegisterRequest struct {
 	Email        string `json:"email" binding:"required,email"`
 	Name         string `json:"name" binding:"required,min=3"`
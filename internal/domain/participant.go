@@ -14,12 +14,64 @@ type Participant struct {
 	QRSentAt    *time.Time `json:"qr_sent_at"`
 	CheckedIn   bool       `json:"checked_in"`
 	CheckedInAt *time.Time `json:"checked_in_at"`
+	IsInside    bool       `json:"is_inside"`   // Sedang berada di dalam venue
+	EntryCount  int        `json:"entry_count"` // Jumlah entry yang diterima
 	CreatedAt   time.Time  `json:"created_at"`
 
+	// Attribute sesuai schema event dan kolom tambahan dari file import yang bukan name, email atau phone
+	Attributes map[string]string `json:"attributes,omitempty"`
+
 	// QR Code URL (generated, tidak disimpan di DB)
 	QRCodeURL string `json:"qr_code_url,omitempty"`
 }
 
+// PhoneChange perubahan phone participant saat normalisasi phone lama
+// Hanya diterapkan jika phone participant masih OldPhone
+type PhoneChange struct {
+	ParticipantID int64
+	OldPhone      string
+	NewPhone      string
+}
+
+const (
+	ParticipantSortName        = "name"
+	ParticipantSortCreatedAt   = "created_at"
+	ParticipantSortCheckedInAt = "checked_in_at"
+)
+
+// ParticipantListRequest query list participant dengan pagination, filter dan sort
+type ParticipantListRequest struct {
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	CheckedIn *bool  `form:"checked_in"`
+	QRSent    *bool  `form:"qr_sent"`
+	Query     string `form:"q" binding:"max=100"`
+	Sort      string `form:"sort" binding:"omitempty,oneof=name created_at checked_in_at"`
+	Order     string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// ParticipantFilter filter dan urutan untuk query list participant di repository
+// Filter bernilai nil atau kosong tidak dipakai
+type ParticipantFilter struct {
+	CheckedIn   *bool
+	QRSent      *bool
+	Query       string
+	PhonePrefix string // awalan phone E.164 dari Query, kosong berarti phone dicocokkan dengan Query
+	SortBy      string
+	SortDesc    bool
+	Limit       int
+	Offset      int
+}
+
+// ParticipantListResponse response list participant dengan pagination
+type ParticipantListResponse struct {
+	Participants []*Participant `json:"participants"`
+	Total        int            `json:"total"`
+	Page         int            `json:"page"`
+	Limit        int            `json:"limit"`
+	TotalPage    int            `json:"total_page"`
+}
+
 // ParticipantCSVRow struktur untuk parse CSV
 type ParticipantCSVRow struct {
 	Name  string `csv:"name"`  // Kolom "name" di CSV
@@ -27,6 +79,23 @@ type ParticipantCSVRow struct {
 	Phone string `csv:"phone"` // Kolom "phone" di CSV
 }
 
+// CreateParticipantRequest request menambah satu participant
+type CreateParticipantRequest struct {
+	Name       string            `json:"name" binding:"required,min=2,max=255"`
+	Email      string            `json:"email" binding:"required,email,max=255"`
+	Phone      string            `json:"phone" binding:"required,max=20"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// UpdateParticipantRequest request mengubah data participant
+// Field kosong tidak diubah, attribute dengan nilai kosong dihapus
+type UpdateParticipantRequest struct {
+	Name       string            `json:"name" binding:"omitempty,min=2,max=255"`
+	Email      string            `json:"email" binding:"omitempty,email,max=255"`
+	Phone      string            `json:"phone" binding:"omitempty,max=20"`
+	Attributes map[string]string `json:"attributes"`
+}
+
 // UploadParticipantsRequest request untuk upload CSV
 type UploadParticipantsRequest struct {
 	EventID string
@@ -34,9 +103,20 @@ type UploadParticipantsRequest struct {
 
 // UploadParticipantsResponse response setelah upload CSV
 type UploadParticipantsResponse struct {
-	Success       int      `json:"success"`
-	Failed        int      `json:"failed"`
-	FailedReasons []string `json:"failed_reasons"`
+	Mode          string            `json:"mode"`
+	Success       int               `json:"success"` // created + updated
+	Created       int               `json:"created"`
+	Updated       int               `json:"updated"`
+	Skipped       int               `json:"skipped"`
+	Failed        int               `json:"failed"`
+	FailedReasons []string          `json:"failed_reasons"`
+	Errors        []*ImportRowError `json:"errors"`
+
+	// Catatan untuk row yang tetap diimport, misalnya email yang kemungkinan salah ketik
+	Warnings []*ImportRowWarning `json:"warnings"`
+
+	// Header CSV asli, dipakai untuk membuat CSV row yang ditolak
+	Headers []string `json:"-"`
 }
 
 type SendQRCodesRequest struct {
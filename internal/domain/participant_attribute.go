@@ -0,0 +1,377 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Tipe nilai attribute participant
+const (
+	AttributeTypeText    = "text"
+	AttributeTypeNumber  = "number"
+	AttributeTypeDate    = "date"
+	AttributeTypeBoolean = "boolean"
+)
+
+// AttributeDateFormat format penyimpanan attribute bertipe date
+const AttributeDateFormat = "2006-01-02"
+
+// Batas schema attribute per event
+const (
+	MaxAttributeDefinitions = 50
+	MaxAttributeNameLength  = 100
+	MaxAttributeValues      = 100
+)
+
+// attributeDateLayouts format tanggal yang diterima dari import dan request
+var attributeDateLayouts = []string{AttributeDateFormat, "02-01-2006", "02/01/2006"}
+
+// attributeBooleans nilai boolean yang diterima, ditulis lowercase
+var attributeBooleans = map[string]bool{
+	"true": true, "yes": true, "ya": true, "y": true, "1": true,
+	"false": false, "no": false, "tidak": false, "n": false, "0": false,
+}
+
+// reservedAttributeNames nama kolom participant yang tidak boleh dipakai sebagai attribute
+var reservedAttributeNames = append([]string{ParticipantFieldIgnore}, participantExportColumns...)
+
+// AttributeDefinition definisi satu attribute participant, misalnya ukuran kaos atau nomor tiket
+type AttributeDefinition struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+
+	// Nilai yang boleh dipakai, kosong berarti bebas. Hanya untuk tipe text dan number
+	AllowedValues []string `json:"allowed_values,omitempty"`
+}
+
+// AttributeSchema daftar attribute participant di event, urutan dipakai untuk kolom export dan email
+// Nama attribute dibandingkan tanpa membedakan huruf besar kecil dan tanda baca
+type AttributeSchema []AttributeDefinition
+
+// AttributeSchemaRequest request menyimpan schema attribute event
+// Schema kosong menghapus schema yang tersimpan
+type AttributeSchemaRequest struct {
+	Attributes AttributeSchema `json:"attributes" binding:"required"`
+}
+
+// AttributeValue nilai attribute participant sesuai urutan schema
+type AttributeValue struct {
+	Name  string
+	Value string
+}
+
+// AttributeError attribute participant yang tidak sesuai schema
+type AttributeError struct {
+	Attribute string
+	Value     string
+	Message   string
+	Err       error // ErrAttributeRequired atau ErrInvalidAttribute
+}
+
+func (e *AttributeError) Error() string {
+	return fmt.Sprintf("attribute '%s' %s", e.Attribute, e.Message)
+}
+
+func (e *AttributeError) Unwrap() error {
+	return e.Err
+}
+
+// Normalize return schema dengan nama, tipe dan allowed values yang sudah di-trim
+// Tipe kosong dianggap text
+func (s AttributeSchema) Normalize() AttributeSchema {
+	normalized := make(AttributeSchema, 0, len(s))
+	for _, def := range s {
+		def.Name = strings.TrimSpace(def.Name)
+		def.Type = strings.ToLower(strings.TrimSpace(def.Type))
+		if def.Type == "" {
+			def.Type = AttributeTypeText
+		}
+
+		var values []string
+		for _, value := range def.AllowedValues {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		def.AllowedValues = values
+
+		normalized = append(normalized, def)
+	}
+
+	return normalized
+}
+
+// Validate memastikan nama attribute unik, tipe dikenali dan allowed values sesuai tipe
+func (s AttributeSchema) Validate() error {
+	if len(s) > MaxAttributeDefinitions {
+		return fmt.Errorf("%w: at most %d attributes", ErrInvalidAttributeSchema, MaxAttributeDefinitions)
+	}
+
+	names := make(map[string]string, len(s))
+	for _, def := range s {
+		key := attributeKey(def.Name)
+		if key == "" {
+			return fmt.Errorf("%w: attribute name is required", ErrInvalidAttributeSchema)
+		}
+
+		if len(def.Name) > MaxAttributeNameLength {
+			return fmt.Errorf("%w: attribute name '%s' is longer than %d characters", ErrInvalidAttributeSchema, def.Name, MaxAttributeNameLength)
+		}
+
+		for _, reserved := range reservedAttributeNames {
+			if key == attributeKey(reserved) {
+				return fmt.Errorf("%w: attribute name '%s' is reserved", ErrInvalidAttributeSchema, def.Name)
+			}
+		}
+
+		if first, exists := names[key]; exists {
+			return fmt.Errorf("%w: attributes '%s' and '%s' have the same name", ErrInvalidAttributeSchema, first, def.Name)
+		}
+		names[key] = def.Name
+
+		if err := def.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *AttributeDefinition) validate() error {
+	switch d.Type {
+	case AttributeTypeText, AttributeTypeNumber:
+	case AttributeTypeDate, AttributeTypeBoolean:
+		if len(d.AllowedValues) > 0 {
+			return fmt.Errorf("%w: attribute '%s' of type %s cannot have allowed values", ErrInvalidAttributeSchema, d.Name, d.Type)
+		}
+	default:
+		return fmt.Errorf("%w: attribute '%s' has unknown type '%s' (use text, number, date or boolean)", ErrInvalidAttributeSchema, d.Name, d.Type)
+	}
+
+	if len(d.AllowedValues) > MaxAttributeValues {
+		return fmt.Errorf("%w: attribute '%s' has more than %d allowed values", ErrInvalidAttributeSchema, d.Name, MaxAttributeValues)
+	}
+
+	seen := make(map[string]bool, len(d.AllowedValues))
+	for _, value := range d.AllowedValues {
+		if d.Type == AttributeTypeNumber {
+			if _, ok := parseAttributeNumber(value); !ok {
+				return fmt.Errorf("%w: allowed value '%s' of attribute '%s' is not a number", ErrInvalidAttributeSchema, value, d.Name)
+			}
+		}
+
+		key := strings.ToLower(value)
+		if seen[key] {
+			return fmt.Errorf("%w: attribute '%s' has duplicate allowed value '%s'", ErrInvalidAttributeSchema, d.Name, value)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// Apply memvalidasi attribute participant sesuai schema dan return attribute yang sudah dinormalisasi
+// Key attribute diganti dengan nama di schema, nilai number, date dan boolean ditulis dalam format baku
+// Attribute yang tidak ada di schema tetap disimpan apa adanya
+// Return *AttributeError untuk attribute pertama yang tidak valid sesuai urutan schema
+func (s AttributeSchema) Apply(attributes map[string]string) (map[string]string, error) {
+	if len(s) == 0 {
+		return attributes, nil
+	}
+
+	// Key dari file import mengikuti header, jadi dicocokkan dengan nama attribute di schema
+	keys := make(map[string]string, len(attributes))
+	for key := range attributes {
+		keys[attributeKey(key)] = key
+	}
+
+	applied := make(map[string]string, len(attributes))
+	matched := make(map[string]bool, len(attributes))
+	for _, def := range s {
+		key, ok := keys[attributeKey(def.Name)]
+		value := ""
+		if ok {
+			matched[key] = true
+			value = strings.TrimSpace(attributes[key])
+		}
+
+		if value == "" {
+			if def.Required {
+				return nil, &AttributeError{Attribute: def.Name, Message: "is required", Err: ErrAttributeRequired}
+			}
+			continue
+		}
+
+		normalized, err := def.normalizeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		applied[def.Name] = normalized
+	}
+
+	for key, value := range attributes {
+		if matched[key] || strings.TrimSpace(value) == "" {
+			continue
+		}
+		applied[key] = strings.TrimSpace(value)
+	}
+
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	return applied, nil
+}
+
+func (d *AttributeDefinition) normalizeValue(value string) (string, error) {
+	invalid := func(message string) error {
+		return &AttributeError{Attribute: d.Name, Value: value, Message: message, Err: ErrInvalidAttribute}
+	}
+
+	switch d.Type {
+	case AttributeTypeNumber:
+		number, ok := parseAttributeNumber(value)
+		if !ok {
+			return "", invalid("must be a number")
+		}
+		value = strconv.FormatFloat(number, 'f', -1, 64)
+
+	case AttributeTypeDate:
+		date, ok := parseAttributeDate(value)
+		if !ok {
+			return "", invalid("must be a date (YYYY-MM-DD)")
+		}
+		return date.Format(AttributeDateFormat), nil
+
+	case AttributeTypeBoolean:
+		b, ok := attributeBooleans[strings.ToLower(value)]
+		if !ok {
+			return "", invalid("must be true or false")
+		}
+		return strconv.FormatBool(b), nil
+	}
+
+	if len(d.AllowedValues) == 0 {
+		return value, nil
+	}
+
+	// Nilai disimpan sesuai penulisan di schema
+	for _, allowed := range d.AllowedValues {
+		if d.Type == AttributeTypeNumber {
+			if number, _ := parseAttributeNumber(allowed); strconv.FormatFloat(number, 'f', -1, 64) == value {
+				return value, nil
+			}
+		} else if strings.EqualFold(allowed, value) {
+			return allowed, nil
+		}
+	}
+
+	return "", invalid("must be one of: " + strings.Join(d.AllowedValues, ", "))
+}
+
+// Columns return nama kolom attribute untuk export: attribute di schema sesuai urutan,
+// lalu key lain yang dimiliki participant tetapi tidak ada di schema
+// Key yang penulisannya sama hanya menjadi satu kolom
+func (s AttributeSchema) Columns(keys []string) []string {
+	columns := make([]string, 0, len(s)+len(keys))
+	defined := make(map[string]bool, len(s)+len(keys))
+	for _, def := range s {
+		columns = append(columns, def.Name)
+		defined[attributeKey(def.Name)] = true
+	}
+
+	for _, key := range keys {
+		if !defined[attributeKey(key)] {
+			columns = append(columns, key)
+			defined[attributeKey(key)] = true
+		}
+	}
+
+	return columns
+}
+
+// Values return nilai attribute participant yang ada di schema sesuai urutan schema
+// Attribute yang kosong tidak disertakan
+func (s AttributeSchema) Values(attributes map[string]string) []AttributeValue {
+	var values []AttributeValue
+	for _, def := range s {
+		if value := LookupAttribute(attributes, def.Name); value != "" {
+			values = append(values, AttributeValue{Name: def.Name, Value: value})
+		}
+	}
+
+	return values
+}
+
+// LookupAttribute mengambil nilai attribute berdasarkan nama tanpa membedakan penulisan
+// Participant dari sebelum schema dibuat bisa menyimpan key "company" untuk attribute "Company"
+func LookupAttribute(attributes map[string]string, name string) string {
+	if value, ok := attributes[name]; ok {
+		return value
+	}
+
+	key := attributeKey(name)
+	for existing, value := range attributes {
+		if attributeKey(existing) == key {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// MergeAttributes menambahkan changes ke attribute current tanpa mengubah current
+// Key yang penulisannya sama dengan key di current menggantikan key tersebut, nilai kosong menghapus attribute
+func MergeAttributes(current, changes map[string]string) map[string]string {
+	merged := make(map[string]string, len(current)+len(changes))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	for key, value := range changes {
+		for existing := range merged {
+			if attributeKey(existing) == attributeKey(key) {
+				delete(merged, existing)
+			}
+		}
+
+		if value = strings.TrimSpace(value); value != "" {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+// attributeKey menyamakan penulisan nama attribute, misalnya "T-Shirt Size" dan "t shirt_size"
+func attributeKey(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(fields, " ")
+}
+
+func parseAttributeNumber(value string) (float64, bool) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, false
+	}
+
+	return number, true
+}
+
+func parseAttributeDate(value string) (time.Time, bool) {
+	for _, layout := range attributeDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+
+	return time.Time{}, false
+}
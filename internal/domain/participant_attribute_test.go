@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var testAttributeSchema = AttributeSchema{
+	{Name: "Company", Type: AttributeTypeText, Required: true},
+	{Name: "T-Shirt Size", Type: AttributeTypeText, AllowedValues: []string{"S", "M", "L"}},
+	{Name: "Ticket Number", Type: AttributeTypeNumber},
+	{Name: "Birth Date", Type: AttributeTypeDate},
+	{Name: "Vegetarian", Type: AttributeTypeBoolean},
+}
+
+func TestAttributeSchema_Validate(t *testing.T) {
+	if err := testAttributeSchema.Validate(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	tests := []struct {
+		name   string
+		schema AttributeSchema
+	}{
+		{"Empty name", AttributeSchema{{Name: " ", Type: AttributeTypeText}}},
+		{"Reserved name", AttributeSchema{{Name: "Email", Type: AttributeTypeText}}},
+		{"Duplicate name", AttributeSchema{{Name: "Shirt Size", Type: AttributeTypeText}, {Name: "shirt_size", Type: AttributeTypeText}}},
+		{"Unknown type", AttributeSchema{{Name: "Company", Type: "string"}}},
+		{"Allowed values for boolean", AttributeSchema{{Name: "Vegetarian", Type: AttributeTypeBoolean, AllowedValues: []string{"yes"}}}},
+		{"Allowed value not a number", AttributeSchema{{Name: "Table", Type: AttributeTypeNumber, AllowedValues: []string{"1", "two"}}}},
+		{"Duplicate allowed value", AttributeSchema{{Name: "Size", Type: AttributeTypeText, AllowedValues: []string{"M", "m"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.schema.Validate(); !errors.Is(err, ErrInvalidAttributeSchema) {
+				t.Errorf("Expected ErrInvalidAttributeSchema, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAttributeSchema_Normalize(t *testing.T) {
+	schema := AttributeSchema{{Name: " Size ", AllowedValues: []string{" S ", "", "M"}}}.Normalize()
+
+	expected := AttributeSchema{{Name: "Size", Type: AttributeTypeText, AllowedValues: []string{"S", "M"}}}
+	if !reflect.DeepEqual(schema, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, schema)
+	}
+}
+
+func TestAttributeSchema_Apply(t *testing.T) {
+	attributes, err := testAttributeSchema.Apply(map[string]string{
+		"company":       " Acme ",
+		"t_shirt_size":  "m",
+		"Ticket Number": "007",
+		"birth date":    "17-08-1990",
+		"VEGETARIAN":    "ya",
+		"Kota":          "Bandung",
+		"Catatan":       " ",
+	})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expected := map[string]string{
+		"Company":       "Acme",
+		"T-Shirt Size":  "M",
+		"Ticket Number": "7",
+		"Birth Date":    "1990-08-17",
+		"Vegetarian":    "true",
+		"Kota":          "Bandung",
+	}
+	if !reflect.DeepEqual(attributes, expected) {
+		t.Errorf("Expected %v, got %v", expected, attributes)
+	}
+}
+
+func TestAttributeSchema_Apply_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		attribute  string
+		err        error
+	}{
+		{"Missing required", map[string]string{"T-Shirt Size": "M"}, "Company", ErrAttributeRequired},
+		{"Empty required", map[string]string{"Company": " "}, "Company", ErrAttributeRequired},
+		{"Not allowed", map[string]string{"Company": "Acme", "T-Shirt Size": "XL"}, "T-Shirt Size", ErrInvalidAttribute},
+		{"Not a number", map[string]string{"Company": "Acme", "Ticket Number": "A-12"}, "Ticket Number", ErrInvalidAttribute},
+		{"Not a date", map[string]string{"Company": "Acme", "Birth Date": "1990-17-08"}, "Birth Date", ErrInvalidAttribute},
+		{"Not a boolean", map[string]string{"Company": "Acme", "Vegetarian": "maybe"}, "Vegetarian", ErrInvalidAttribute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testAttributeSchema.Apply(tt.attributes)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Expected %v, got %v", tt.err, err)
+			}
+
+			var attrErr *AttributeError
+			if !errors.As(err, &attrErr) || attrErr.Attribute != tt.attribute {
+				t.Errorf("Expected error for attribute %s, got %v", tt.attribute, err)
+			}
+		})
+	}
+}
+
+func TestAttributeSchema_Apply_NoSchema(t *testing.T) {
+	attributes := map[string]string{"Kota": "Bandung"}
+
+	got, err := AttributeSchema(nil).Apply(attributes)
+	if err != nil || !reflect.DeepEqual(got, attributes) {
+		t.Errorf("Expected attributes unchanged, got %v (%v)", got, err)
+	}
+}
+
+func TestAttributeSchema_Columns(t *testing.T) {
+	columns := testAttributeSchema[:2].Columns([]string{"Kota", "company", "Instansi", "kota"})
+
+	expected := []string{"Company", "T-Shirt Size", "Kota", "Instansi"}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("Expected %v, got %v", expected, columns)
+	}
+}
+
+func TestAttributeSchema_Values(t *testing.T) {
+	// Key dari sebelum schema dibuat tetap ditemukan walaupun penulisannya berbeda
+	values := testAttributeSchema.Values(map[string]string{"Vegetarian": "true", "company": "Acme", "Kota": "Bandung"})
+
+	expected := []AttributeValue{{Name: "Company", Value: "Acme"}, {Name: "Vegetarian", Value: "true"}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
+
+func TestLookupAttribute(t *testing.T) {
+	attributes := map[string]string{"t_shirt_size": "M", "Kota": "Bandung"}
+
+	if got := LookupAttribute(attributes, "T-Shirt Size"); got != "M" {
+		t.Errorf("Expected M, got %q", got)
+	}
+	if got := LookupAttribute(attributes, "Kota"); got != "Bandung" {
+		t.Errorf("Expected Bandung, got %q", got)
+	}
+	if got := LookupAttribute(attributes, "Company"); got != "" {
+		t.Errorf("Expected empty value, got %q", got)
+	}
+}
+
+func TestMergeAttributes(t *testing.T) {
+	current := map[string]string{"Company": "Acme", "Kota": "Bandung"}
+
+	merged := MergeAttributes(current, map[string]string{"company": "Globex", "Kota": "", "Size": "M"})
+
+	expected := map[string]string{"company": "Globex", "Size": "M"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected %v, got %v", expected, merged)
+	}
+
+	if current["Company"] != "Acme" || current["Kota"] != "Bandung" {
+		t.Errorf("Expected current attributes unchanged, got %v", current)
+	}
+}
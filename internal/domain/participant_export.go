@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Format file export participant
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportTimeFormat format waktu qr_sent_at dan checked_in_at di file export
+const ExportTimeFormat = "2006-01-02 15:04:05"
+
+// ParticipantExportRequest query export participant, filter dan sort sama dengan list participant
+type ParticipantExportRequest struct {
+	Format    string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	CheckedIn *bool  `form:"checked_in"`
+	QRSent    *bool  `form:"qr_sent"`
+	Query     string `form:"q" binding:"max=100"`
+	Sort      string `form:"sort" binding:"omitempty,oneof=name created_at checked_in_at"`
+	Order     string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// participantExportColumns kolom tetap file export, diikuti kolom attribute
+var participantExportColumns = []string{"name", "email", "phone", "qr_sent_at", "checked_in_at"}
+
+// ParticipantExport export participant yang sudah dicek aksesnya dan siap ditulis
+type ParticipantExport struct {
+	EventID    string
+	Format     string
+	FileName   string
+	Filter     *ParticipantFilter
+	Attributes []string // key attribute yang menjadi kolom setelah kolom tetap
+}
+
+// Header header file export
+func (e *ParticipantExport) Header() []string {
+	header := make([]string, 0, len(participantExportColumns)+len(e.Attributes))
+	header = append(header, participantExportColumns...)
+
+	return append(header, e.Attributes...)
+}
+
+// Record isi satu row file export untuk participant, urutan sama dengan Header
+// Waktu yang kosong dan attribute yang tidak dimiliki participant ditulis sebagai string kosong
+// Attribute dicocokkan dengan kolom tanpa membedakan penulisan key
+func (e *ParticipantExport) Record(p *Participant) []string {
+	record := make([]string, 0, len(participantExportColumns)+len(e.Attributes))
+	record = append(record, p.Name, p.Email, p.Phone, formatExportTime(p.QRSentAt), formatExportTime(p.CheckedInAt))
+
+	for _, key := range e.Attributes {
+		record = append(record, LookupAttribute(p.Attributes, key))
+	}
+
+	return record
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(ExportTimeFormat)
+}
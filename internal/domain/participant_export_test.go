@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParticipantExport_Record(t *testing.T) {
+	export := &ParticipantExport{Attributes: []string{"Instansi", "Kota"}}
+
+	header := []string{"name", "email", "phone", "qr_sent_at", "checked_in_at", "Instansi", "Kota"}
+	if got := export.Header(); !reflect.DeepEqual(got, header) {
+		t.Errorf("Expected header %v, got %v", header, got)
+	}
+
+	checkedInAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	participant := &Participant{
+		Name:        "John Doe",
+		Email:       "john@example.com",
+		Phone:       "08123456789",
+		CheckedInAt: &checkedInAt,
+		Attributes:  map[string]string{"kota": "Bandung"},
+	}
+
+	record := []string{"John Doe", "john@example.com", "08123456789", "", "2026-03-01 09:30:00", "", "Bandung"}
+	if got := export.Record(participant); !reflect.DeepEqual(got, record) {
+		t.Errorf("Expected record %v, got %v", record, got)
+	}
+}
@@ -0,0 +1,294 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kode error untuk row yang ditolak saat import participant
+const (
+	ImportErrorRequired         = "required"
+	ImportErrorInvalidEmail     = "invalid_email"
+	ImportErrorInvalidPhone     = "invalid_phone"
+	ImportErrorInvalidAttribute = "invalid_attribute"
+	ImportErrorMalformedRow     = "malformed_row"
+	ImportErrorDuplicate        = "duplicate"
+	ImportErrorDuplicateInFile  = "duplicate_in_file"
+)
+
+// Mode import untuk participant yang sudah terdaftar di event (email atau phone sama)
+const (
+	ImportModeReject = "reject" // Row ditolak sebagai row error
+	ImportModeSkip   = "skip"   // Row dilewati tanpa mengubah data
+	ImportModeUpdate = "update" // Data participant yang sudah ada diupdate
+)
+
+// Field participant yang bisa menjadi tujuan column mapping
+const (
+	ParticipantFieldName   = "name"
+	ParticipantFieldEmail  = "email"
+	ParticipantFieldPhone  = "phone"
+	ParticipantFieldIgnore = "ignore" // Kolom dibuang, tidak disimpan sebagai attribute
+)
+
+// ColumnMapping memetakan header CSV ke field participant
+// Header dibandingkan tanpa membedakan huruf besar kecil
+type ColumnMapping map[string]string
+
+// Validate memastikan semua tujuan mapping adalah field participant
+func (m ColumnMapping) Validate() error {
+	for header, field := range m {
+		switch field {
+		case ParticipantFieldName, ParticipantFieldEmail, ParticipantFieldPhone, ParticipantFieldIgnore:
+		default:
+			return fmt.Errorf("%w: column '%s' mapped to unknown field '%s'", ErrInvalidColumnMapping, header, field)
+		}
+	}
+
+	return nil
+}
+
+// Merge menggabungkan mapping, mapping dari override dipakai jika header sama
+func (m ColumnMapping) Merge(override ColumnMapping) ColumnMapping {
+	merged := make(ColumnMapping, len(m)+len(override))
+	for header, field := range m {
+		merged[strings.ToLower(strings.TrimSpace(header))] = field
+	}
+	for header, field := range override {
+		merged[strings.ToLower(strings.TrimSpace(header))] = field
+	}
+
+	return merged
+}
+
+// Format file import participant
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatXLSX = "xlsx"
+)
+
+// ImportOptions opsi import participant dari file
+type ImportOptions struct {
+	Mode    string        `json:"mode"`
+	Mapping ColumnMapping `json:"mapping,omitempty"`
+
+	// Format file, default csv
+	Format string `json:"format"`
+	// Nama sheet untuk file xlsx, kosong berarti sheet pertama
+	Sheet string `json:"sheet,omitempty"`
+	// Charset file csv, kosong berarti dideteksi otomatis
+	Charset string `json:"charset,omitempty"`
+}
+
+// ImportRowError error untuk satu row yang ditolak saat import participant
+// Row dihitung seperti di spreadsheet, header adalah row 1
+type ImportRowError struct {
+	Row       int    `json:"row"`
+	Column    string `json:"column,omitempty"`
+	Value     string `json:"value"`
+	ErrorCode string `json:"error_code"`
+	Message   string `json:"message"`
+
+	// Isi row asli, dipakai untuk membuat CSV row yang ditolak
+	Record []string `json:"-"`
+}
+
+func (e *ImportRowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+// Kode warning untuk row yang tetap diimport tetapi perlu dicek organizer
+const ImportWarningEmailTypo = "email_typo"
+
+// ImportRowWarning catatan untuk row yang tetap diimport, misalnya email yang kemungkinan salah ketik
+type ImportRowWarning struct {
+	Row         int    `json:"row"`
+	Column      string `json:"column,omitempty"`
+	Value       string `json:"value"`
+	WarningCode string `json:"warning_code"`
+	Message     string `json:"message"`
+	Suggestion  string `json:"suggestion,omitempty"`
+}
+
+// ValidateImportMode memastikan mode import dikenali
+func ValidateImportMode(mode string) error {
+	switch mode {
+	case ImportModeReject, ImportModeSkip, ImportModeUpdate:
+		return nil
+	default:
+		return ErrInvalidImportMode
+	}
+}
+
+// Action untuk setiap participant hasil import
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionSkip   = "skip"
+	ImportActionReject = "reject"
+)
+
+// ImportResult hasil menyimpan participant hasil import ke database
+type ImportResult struct {
+	Created int
+	Updated int
+	Skipped int
+
+	// Index participant yang ditolak karena sudah terdaftar (mode reject)
+	Rejected []int
+
+	// Action untuk setiap participant sesuai urutan participant yang diimport
+	Actions []string
+
+	// Jumlah participant event sebelum import
+	Registered int
+}
+
+// ImportPlan perubahan data yang akan dilakukan oleh import participant
+type ImportPlan struct {
+	Creates []*Participant
+	Updates []*Participant // participant lama yang sudah digabung dengan data dari file
+	Result  *ImportResult
+}
+
+// PlanImport mencocokkan participant dari file dengan participant event yang sudah ada
+// dan menentukan action untuk setiap participant sesuai mode import
+// Participant lama yang cocok diubah langsung pada mode update
+func PlanImport(existing, participants []*Participant, mode string) *ImportPlan {
+	matcher := NewParticipantMatcher(existing)
+	plan := &ImportPlan{
+		Result: &ImportResult{
+			Actions:    make([]string, len(participants)),
+			Registered: len(existing),
+		},
+	}
+
+	for i, p := range participants {
+		match := matcher.Match(p)
+		if match == nil {
+			plan.Creates = append(plan.Creates, p)
+			plan.Result.Actions[i] = ImportActionCreate
+			continue
+		}
+
+		switch mode {
+		case ImportModeUpdate:
+			if !match.MergeImport(p) {
+				plan.Result.Skipped++
+				plan.Result.Actions[i] = ImportActionSkip
+				continue
+			}
+
+			p.ID = match.ID
+			plan.Updates = append(plan.Updates, match)
+			plan.Result.Actions[i] = ImportActionUpdate
+		case ImportModeSkip:
+			plan.Result.Skipped++
+			plan.Result.Actions[i] = ImportActionSkip
+		default:
+			plan.Result.Rejected = append(plan.Result.Rejected, i)
+			plan.Result.Actions[i] = ImportActionReject
+		}
+	}
+
+	plan.Result.Created = len(plan.Creates)
+	plan.Result.Updated = len(plan.Updates)
+
+	return plan
+}
+
+// CheckQuota memastikan participant baru dari import masih muat di kuota event
+func (r *ImportResult) CheckQuota(quota int) error {
+	return CheckParticipantQuota(quota, r.Registered, r.Created)
+}
+
+// ParticipantMatcher mencari participant yang sama berdasarkan email atau phone
+// Email dibandingkan tanpa membedakan huruf besar kecil
+type ParticipantMatcher struct {
+	byEmail map[string]*Participant
+	byPhone map[string]*Participant
+}
+
+// NewParticipantMatcher membuat matcher dari daftar participant
+func NewParticipantMatcher(participants []*Participant) *ParticipantMatcher {
+	m := &ParticipantMatcher{
+		byEmail: make(map[string]*Participant, len(participants)),
+		byPhone: make(map[string]*Participant, len(participants)),
+	}
+
+	for _, p := range participants {
+		m.Add(p)
+	}
+
+	return m
+}
+
+// Add menambah participant ke matcher, participant pertama dengan email atau phone yang sama tetap dipakai
+func (m *ParticipantMatcher) Add(p *Participant) {
+	if email := matchKey(p.Email); email != "" {
+		if _, exists := m.byEmail[email]; !exists {
+			m.byEmail[email] = p
+		}
+	}
+
+	if phone := strings.TrimSpace(p.Phone); phone != "" {
+		if _, exists := m.byPhone[phone]; !exists {
+			m.byPhone[phone] = p
+		}
+	}
+}
+
+// Match mencari participant dengan email yang sama, lalu phone yang sama
+// Return nil jika tidak ada yang cocok
+func (m *ParticipantMatcher) Match(p *Participant) *Participant {
+	if existing, ok := m.byEmail[matchKey(p.Email)]; ok {
+		return existing
+	}
+
+	if existing, ok := m.byPhone[strings.TrimSpace(p.Phone)]; ok {
+		return existing
+	}
+
+	return nil
+}
+
+func matchKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ChangeEmail mengganti email participant
+// Status QR direset supaya ticket dikirim ulang ke email baru,
+// perubahan huruf besar kecil saja tidak dianggap email baru
+func (p *Participant) ChangeEmail(email string) {
+	if !strings.EqualFold(email, p.Email) {
+		p.QRSent = false
+		p.QRSentAt = nil
+	}
+
+	p.Email = email
+}
+
+// MergeImport mengupdate data participant dengan data dari row import
+// Attribute dari row import ditambahkan ke attribute yang sudah ada
+// Return true jika ada data yang berubah
+func (p *Participant) MergeImport(src *Participant) bool {
+	changed := p.Name != src.Name || p.Phone != src.Phone || p.Email != src.Email
+
+	p.Name = src.Name
+	p.Phone = src.Phone
+	p.ChangeEmail(src.Email)
+
+	for key, value := range src.Attributes {
+		if current, ok := p.Attributes[key]; ok && current == value {
+			continue
+		}
+
+		if p.Attributes == nil {
+			p.Attributes = make(map[string]string, len(src.Attributes))
+		}
+		p.Attributes[key] = value
+		changed = true
+	}
+
+	return changed
+}
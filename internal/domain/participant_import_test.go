@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParticipantMatcher(t *testing.T) {
+	existing := []*Participant{
+		{ID: 1, Email: "john@example.com", Phone: "08123456789"},
+		{ID: 2, Email: "jane@example.com", Phone: "08987654321"},
+	}
+
+	matcher := NewParticipantMatcher(existing)
+
+	tests := []struct {
+		name       string
+		p          *Participant
+		expectedID int64
+	}{
+		{"Same email", &Participant{Email: "john@example.com", Phone: "0800"}, 1},
+		{"Email different case", &Participant{Email: " JOHN@Example.com ", Phone: "0800"}, 1},
+		{"Same phone", &Participant{Email: "other@example.com", Phone: "08987654321"}, 2},
+		{"Email match wins over phone", &Participant{Email: "jane@example.com", Phone: "08123456789"}, 2},
+		{"No match", &Participant{Email: "new@example.com", Phone: "0811"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := matcher.Match(tt.p)
+
+			var id int64
+			if match != nil {
+				id = match.ID
+			}
+
+			if id != tt.expectedID {
+				t.Errorf("Match() = %d, expected %d", id, tt.expectedID)
+			}
+		})
+	}
+}
+
+func TestParticipant_MergeImport(t *testing.T) {
+	sentAt := time.Now()
+
+	newParticipant := func() *Participant {
+		return &Participant{Name: "John", Email: "john@example.com", Phone: "0812", QRSent: true, QRSentAt: &sentAt}
+	}
+
+	t.Run("No changes", func(t *testing.T) {
+		p := newParticipant()
+		if p.MergeImport(&Participant{Name: "John", Email: "john@example.com", Phone: "0812"}) {
+			t.Error("Expected no changes")
+		}
+		if !p.IsQRSent() {
+			t.Error("QR status should not be reset")
+		}
+	})
+
+	t.Run("Name and phone changed", func(t *testing.T) {
+		p := newParticipant()
+		if !p.MergeImport(&Participant{Name: "John Doe", Email: "john@example.com", Phone: "0813"}) {
+			t.Error("Expected changes")
+		}
+		if p.Name != "John Doe" || p.Phone != "0813" {
+			t.Errorf("Unexpected data after merge: %s %s", p.Name, p.Phone)
+		}
+		if !p.IsQRSent() {
+			t.Error("QR status should not be reset when email is unchanged")
+		}
+	})
+
+	t.Run("Email changed", func(t *testing.T) {
+		p := newParticipant()
+		if !p.MergeImport(&Participant{Name: "John", Email: "john.doe@example.com", Phone: "0812"}) {
+			t.Error("Expected changes")
+		}
+		if p.QRSent || p.QRSentAt != nil {
+			t.Error("QR status should be reset when email changes")
+		}
+	})
+
+	t.Run("Email case changed", func(t *testing.T) {
+		p := newParticipant()
+		p.MergeImport(&Participant{Name: "John", Email: "John@Example.com", Phone: "0812"})
+		if p.Email != "John@Example.com" {
+			t.Errorf("Expected email to be updated, got %s", p.Email)
+		}
+		if !p.IsQRSent() {
+			t.Error("QR status should not be reset when only email case changes")
+		}
+	})
+}
+
+func TestParticipant_MergeImport_Attributes(t *testing.T) {
+	p := &Participant{Name: "John", Email: "john@example.com", Phone: "0812", Attributes: map[string]string{"Instansi": "UI"}}
+
+	if p.MergeImport(&Participant{Name: "John", Email: "john@example.com", Phone: "0812", Attributes: map[string]string{"Instansi": "UI"}}) {
+		t.Error("Same attributes should not be a change")
+	}
+
+	if !p.MergeImport(&Participant{Name: "John", Email: "john@example.com", Phone: "0812", Attributes: map[string]string{"Ukuran Kaos": "L"}}) {
+		t.Error("New attribute should be a change")
+	}
+
+	if p.Attributes["Instansi"] != "UI" || p.Attributes["Ukuran Kaos"] != "L" {
+		t.Errorf("Expected attributes to be merged, got %v", p.Attributes)
+	}
+}
+
+func TestColumnMapping(t *testing.T) {
+	saved := ColumnMapping{"Nama Peserta": ParticipantFieldName, "Kontak": ParticipantFieldPhone}
+	merged := saved.Merge(ColumnMapping{" KONTAK ": ParticipantFieldIgnore})
+
+	if merged["nama peserta"] != ParticipantFieldName || merged["kontak"] != ParticipantFieldIgnore {
+		t.Errorf("Unexpected merged mapping: %v", merged)
+	}
+
+	if err := (ColumnMapping{"Kota": "city"}).Validate(); !errors.Is(err, ErrInvalidColumnMapping) {
+		t.Errorf("Expected ErrInvalidColumnMapping, got %v", err)
+	}
+}
+
+func TestValidateImportMode(t *testing.T) {
+	for _, mode := range []string{ImportModeReject, ImportModeSkip, ImportModeUpdate} {
+		if err := ValidateImportMode(mode); err != nil {
+			t.Errorf("Mode %s should be valid, got %v", mode, err)
+		}
+	}
+
+	if err := ValidateImportMode("replace"); err != ErrInvalidImportMode {
+		t.Errorf("Expected ErrInvalidImportMode, got %v", err)
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	newExisting := func() []*Participant {
+		return []*Participant{
+			{ID: 1, Name: "John Doe", Email: "john@example.com", Phone: "08123456789"},
+			{ID: 2, Name: "Jane", Email: "jane@example.com", Phone: "08987654321"},
+		}
+	}
+
+	newImport := func() []*Participant {
+		return []*Participant{
+			{Name: "John Smith", Email: "john@example.com", Phone: "08123456789"},
+			{Name: "Jane", Email: "jane@example.com", Phone: "08987654321"},
+			{Name: "Bob", Email: "bob@example.com", Phone: "0811"},
+		}
+	}
+
+	tests := []struct {
+		mode     string
+		actions  []string
+		created  int
+		updated  int
+		skipped  int
+		rejected []int
+	}{
+		{ImportModeReject, []string{ImportActionReject, ImportActionReject, ImportActionCreate}, 1, 0, 0, []int{0, 1}},
+		{ImportModeSkip, []string{ImportActionSkip, ImportActionSkip, ImportActionCreate}, 1, 0, 2, nil},
+		{ImportModeUpdate, []string{ImportActionUpdate, ImportActionSkip, ImportActionCreate}, 1, 1, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			plan := PlanImport(newExisting(), newImport(), tt.mode)
+			result := plan.Result
+
+			if result.Created != tt.created || result.Updated != tt.updated || result.Skipped != tt.skipped {
+				t.Errorf("Expected created %d updated %d skipped %d, got %+v", tt.created, tt.updated, tt.skipped, *result)
+			}
+
+			if len(result.Rejected) != len(tt.rejected) {
+				t.Errorf("Expected rejected %v, got %v", tt.rejected, result.Rejected)
+			}
+
+			for i, action := range tt.actions {
+				if result.Actions[i] != action {
+					t.Errorf("Participant %d: expected action %s, got %s", i, action, result.Actions[i])
+				}
+			}
+
+			if result.Registered != 2 {
+				t.Errorf("Expected 2 registered, got %d", result.Registered)
+			}
+
+			if len(plan.Creates) != tt.created || len(plan.Updates) != tt.updated {
+				t.Errorf("Expected %d creates and %d updates, got %d and %d",
+					tt.created, tt.updated, len(plan.Creates), len(plan.Updates))
+			}
+		})
+	}
+}
+
+func TestImportResult_CheckQuota(t *testing.T) {
+	result := &ImportResult{Created: 3, Registered: 8}
+
+	if err := result.CheckQuota(11); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	var quotaErr *QuotaExceededError
+	if err := result.CheckQuota(10); !errors.As(err, &quotaErr) || quotaErr.AdditionalNeeded != 1 {
+		t.Errorf("Expected quota error needing 1 more, got %v", err)
+	}
+}
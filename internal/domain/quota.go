@@ -0,0 +1,76 @@
+package domain
+
+import "fmt"
+
+// BuyCapacityRequest request membeli kuota participant tambahan
+type BuyCapacityRequest struct {
+	AdditionalParticipants int `json:"additional_participants" binding:"required,min=1,max=100000"`
+}
+
+// BuyCapacityResponse kuota dan harga event setelah membeli kuota tambahan
+// Kuota tambahan baru aktif setelah selisih harga dibayar dan diverifikasi
+type BuyCapacityResponse struct {
+	ParticipantCount    int `json:"participant_count"`    // Kuota yang aktif saat ini
+	PendingParticipants int `json:"pending_participants"` // Kuota tambahan yang menunggu pembayaran
+	PreviousTotalPrice  int `json:"previous_total_price"`
+	TotalPrice          int `json:"total_price"`      // Total setelah kuota tambahan dibayar
+	AdditionalPrice     int `json:"additional_price"` // Selisih yang harus dibayar
+}
+
+// QuotaExceededError detail kuota participant yang terlampaui
+type QuotaExceededError struct {
+	Quota      int `json:"quota"`
+	Registered int `json:"registered"`
+	Adding     int `json:"adding"`
+
+	// Kuota yang perlu dibeli dan harganya, diisi oleh usecase
+	AdditionalNeeded int `json:"additional_needed"`
+	AdditionalPrice  int `json:"additional_price"`
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: quota %d, registered %d, adding %d (buy %d more)",
+		ErrParticipantQuotaExceeded, e.Quota, e.Registered, e.Adding, e.AdditionalNeeded)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrParticipantQuotaExceeded
+}
+
+// CheckParticipantQuota memastikan participant baru masih muat di kuota yang dibeli
+// Return *QuotaExceededError jika kuota terlampaui
+func CheckParticipantQuota(quota, registered, adding int) error {
+	if registered+adding <= quota {
+		return nil
+	}
+
+	return &QuotaExceededError{
+		Quota:            quota,
+		Registered:       registered,
+		Adding:           adding,
+		AdditionalNeeded: registered + adding - quota,
+	}
+}
+
+// CapacityUpgrade menghitung participant_count dan total_price setelah membeli kuota tambahan
+// Total price dihitung ulang dengan CalculatePrice untuk jumlah baru, organizer membayar selisihnya
+// Total price tidak pernah turun walaupun jumlah baru masuk tier harga yang lebih murah
+func CapacityUpgrade(currentCount, currentTotal, additional int) (newCount, newTotal, additionalPrice int) {
+	newCount = currentCount + additional
+	newTotal = CalculatePrice(newCount)
+
+	if newTotal < currentTotal {
+		newTotal = currentTotal
+	}
+
+	return newCount, newTotal, newTotal - currentTotal
+}
+
+// PendingCapacity menghitung kuota tambahan yang menunggu pembayaran setelah membeli additional participant lagi
+// Selisih harga selalu dihitung dari kuota yang sudah dibayar, pembelian yang belum dibayar digabung
+func PendingCapacity(paidCount, paidTotal, pendingCount, additional int) (newPending, pendingPrice int) {
+	newPending = pendingCount + additional
+	_, _, pendingPrice = CapacityUpgrade(paidCount, paidTotal, newPending)
+
+	return newPending, pendingPrice
+}
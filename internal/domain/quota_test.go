@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckParticipantQuota(t *testing.T) {
+	if err := CheckParticipantQuota(100, 90, 10); err != nil {
+		t.Errorf("Import that exactly fills the quota should be allowed, got %v", err)
+	}
+
+	err := CheckParticipantQuota(100, 90, 15)
+	if !errors.Is(err, ErrParticipantQuotaExceeded) {
+		t.Fatalf("Expected ErrParticipantQuotaExceeded, got %v", err)
+	}
+
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatal("Expected *QuotaExceededError")
+	}
+
+	if quotaErr.AdditionalNeeded != 5 {
+		t.Errorf("Expected 5 additional participants needed, got %d", quotaErr.AdditionalNeeded)
+	}
+}
+
+func TestCapacityUpgrade(t *testing.T) {
+	tests := []struct {
+		name            string
+		currentCount    int
+		additional      int
+		expectedCount   int
+		expectedTotal   int
+		expectedPayment int
+	}{
+		{"Same tier", 20, 10, 30, 150000, 50000},
+		{"Cross into cheaper tier", 100, 100, 200, 800000, 350000},
+		{"Total never decreases", 50, 1, 51, 250000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentTotal := CalculatePrice(tt.currentCount)
+			count, total, payment := CapacityUpgrade(tt.currentCount, currentTotal, tt.additional)
+
+			if count != tt.expectedCount || total != tt.expectedTotal || payment != tt.expectedPayment {
+				t.Errorf("CapacityUpgrade() = (%d, %d, %d), expected (%d, %d, %d)",
+					count, total, payment, tt.expectedCount, tt.expectedTotal, tt.expectedPayment)
+			}
+		})
+	}
+}
+
+func TestPendingCapacity(t *testing.T) {
+	paidTotal := CalculatePrice(100)
+
+	pending, price := PendingCapacity(100, paidTotal, 0, 50)
+	if pending != 50 || price != CalculatePrice(150)-paidTotal {
+		t.Errorf("PendingCapacity() = (%d, %d), expected (50, %d)", pending, price, CalculatePrice(150)-paidTotal)
+	}
+
+	// Pembelian kedua sebelum dibayar digabung dengan pembelian pertama
+	pending, price = PendingCapacity(100, paidTotal, pending, 50)
+	if pending != 100 || price != CalculatePrice(200)-paidTotal {
+		t.Errorf("PendingCapacity() = (%d, %d), expected (100, %d)", pending, price, CalculatePrice(200)-paidTotal)
+	}
+}
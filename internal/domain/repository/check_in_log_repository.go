@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+// CheckInLogRepository adalah interface untuk akses data check-in log
+type CheckInLogRepository interface {
+	// Record menyimpan log scan lalu menghitung ulang status check-in participant dari log
+	// Dijalankan dalam satu transaction dengan row participant dikunci, result final
+	// ditentukan dari status participant saat itu dengan domain.ResolveScanResult
+	// maxEntries adalah batas entry dari re-entry policy event, 0 berarti tanpa batas
+	// Log undo yang diterima menandai semua entry dan exit sebelumnya sebagai reverted
+	Record(ctx context.Context, log *domain.CheckInLog, maxEntries int) error
+
+	// GetByEventID mencari log di event tertentu dengan pagination, terbaru lebih dulu
+	GetByEventID(ctx context.Context, eventID string, limit, offset int) ([]*domain.CheckInLog, int, error)
+
+	// GetByParticipantID mencari log milik participant dengan pagination, terbaru lebih dulu
+	GetByParticipantID(ctx context.Context, participantID int64, limit, offset int) ([]*domain.CheckInLog, int, error)
+}
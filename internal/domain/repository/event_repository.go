@@ -21,9 +21,31 @@ type EventRepository interface {
 	// offset = (page - 1) * limit
 	GetByOrganizerID(ctx context.Context, organizerID int64, limit, offset int) ([]*domain.Event, int, error)
 
-	// Update mengupdate data event
+	// Update mengupdate data event, kecuali participant_count dan total_price
 	Update(ctx context.Context, event *domain.Event) error
 
+	// AddCapacity menambah kuota yang menunggu pembayaran secara atomik dan menghitung selisih harganya
+	// Kuota yang sudah dibayar tetap berlaku, kuota tambahan tanpa selisih harga langsung aktif
+	AddCapacity(ctx context.Context, eventID string, additional int) (*domain.BuyCapacityResponse, error)
+
+	// ApplyPendingCapacity memindahkan kuota yang menunggu pembayaran ke participant_count dan total_price
+	// Return domain.ErrPendingCapacityNotFound jika tidak ada kuota pending dengan selisih harga paidPrice
+	ApplyPendingCapacity(ctx context.Context, eventID string, paidPrice int) (*domain.BuyCapacityResponse, error)
+
+	// UpdateScannerPIN mengganti hash scanner PIN dan menaikkan scanner_pin_version
+	// sehingga semua scanner session dengan PIN lama tidak berlaku lagi
+	UpdateScannerPIN(ctx context.Context, eventID, pinHash string) error
+
+	// GetPlainScannerPINs mengambil maksimal limit event lama yang scanner PIN nya belum di-hash,
+	// dimulai dari event dengan ID setelah afterID. Hanya ID dan ScannerPINHash yang diisi
+	GetPlainScannerPINs(ctx context.Context, afterID string, limit int) ([]*domain.Event, error)
+
+	// ReplacePlainScannerPIN mengganti PIN plain text dengan hash nya tanpa menaikkan scanner_pin_version
+	ReplacePlainScannerPIN(ctx context.Context, eventID, pin, pinHash string) error
+
+	// UpdateAttributeSchema mengganti schema attribute participant event, schema kosong disimpan sebagai NULL
+	UpdateAttributeSchema(ctx context.Context, eventID string, schema domain.AttributeSchema) error
+
 	// Delete menghapus event (dan cascade delete participants)
 	Delete(ctx context.Context, id string) error
 
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+// ImportJobRepository adalah interface untuk akses data import job
+type ImportJobRepository interface {
+	// Create menyimpan job baru beserta isi file yang akan diimport
+	Create(ctx context.Context, job *domain.ImportJob, content []byte) error
+
+	// GetByID mencari job tanpa isi file, return domain.ErrNotFound jika tidak ada
+	GetByID(ctx context.Context, id string) (*domain.ImportJob, error)
+
+	// GetContent mengambil isi file job yang belum selesai
+	GetContent(ctx context.Context, id string) ([]byte, error)
+
+	// ClaimNext mengambil job queued paling lama dan mengubah statusnya menjadi running
+	// Aman dipanggil dari beberapa worker, return domain.ErrNotFound jika tidak ada job
+	ClaimNext(ctx context.Context) (*domain.ImportJob, error)
+
+	// UpdateProgress menyimpan jumlah row yang sudah diproses dan hasilnya, sekaligus menandai job masih berjalan
+	// Return domain.ErrImportJobNotRunning jika job sudah tidak running, misalnya sudah di-fail oleh FailStale
+	UpdateProgress(ctx context.Context, job *domain.ImportJob) error
+
+	// Finish menyimpan hasil akhir dan error report job, isi file dihapus
+	// Return domain.ErrImportJobNotRunning jika job sudah tidak running
+	Finish(ctx context.Context, job *domain.ImportJob) error
+
+	// FailStale menandai job running yang tidak ada progress sejak before sebagai failed
+	FailStale(ctx context.Context, before time.Time, message string) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+// ImportPreviewRepository adalah interface untuk menyimpan file import hasil dry-run
+type ImportPreviewRepository interface {
+	// Create menyimpan preview baru, preview yang sudah expired ikut dihapus
+	Create(ctx context.Context, preview *domain.ImportPreview) error
+
+	// GetByToken mencari preview berdasarkan import token, return domain.ErrNotFound jika tidak ada
+	GetByToken(ctx context.Context, token string) (*domain.ImportPreview, error)
+
+	// Claim menandai preview yang belum expired sedang dipakai sehingga import token hanya bisa dipakai satu request
+	// Return domain.ErrNotFound jika token tidak ada, sudah expired atau sedang dipakai request lain
+	Claim(ctx context.Context, token string, now time.Time) error
+
+	// Release mengembalikan preview yang sudah di-claim supaya import token bisa dipakai lagi
+	Release(ctx context.Context, token string) error
+
+	// Delete menghapus preview setelah import berhasil
+	Delete(ctx context.Context, token string) error
+}
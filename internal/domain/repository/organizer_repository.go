@@ -10,4 +10,10 @@ type OrganizerRepository interface {
 	Create(ctx context.Context, organizer *domain.Organizer) error
 	GetByEmail(ctx context.Context, email string) (*domain.Organizer, error)
 	GetByID(ctx context.Context, id int64) (*domain.Organizer, error)
+
+	// GetImportMapping mengambil column mapping default import participant milik organizer
+	GetImportMapping(ctx context.Context, id int64) (domain.ColumnMapping, error)
+
+	// UpdateImportMapping menyimpan column mapping default import participant milik organizer
+	UpdateImportMapping(ctx context.Context, id int64, mapping domain.ColumnMapping) error
 }
@@ -9,10 +9,18 @@ import (
 // ParticipantRepository adalah interface untuk akses data participant
 type ParticipantRepository interface {
 	// Create menyimpan satu participant
+	// Return domain.ErrParticipantAlreadyExists jika email sudah terdaftar di event
 	Create(ctx context.Context, participant *domain.Participant) error
 
-	// BulkCreate menyimpan banyak participants sekaligus (untuk CSV upload)
-	BulkCreate(ctx context.Context, participants []*domain.Participant) error
+	// Import menyimpan participant hasil import dalam satu transaction
+	// Participant yang email atau phone-nya sudah terdaftar di event diproses sesuai mode import
+	// Import di event yang sama dijalankan bergantian supaya tidak ada duplikat
+	// Return *domain.QuotaExceededError jika participant baru melebihi quota
+	Import(ctx context.Context, eventID string, participants []*domain.Participant, mode string, quota int) (*domain.ImportResult, error)
+
+	// PreviewImport menghitung hasil Import dengan aturan yang sama tanpa menyimpan data
+	// Kuota tidak dicek, gunakan Registered di result untuk menghitungnya
+	PreviewImport(ctx context.Context, eventID string, participants []*domain.Participant, mode string) (*domain.ImportResult, error)
 
 	// GetByID mencari participant berdasarkan ID
 	GetByID(ctx context.Context, id int64) (*domain.Participant, error)
@@ -20,17 +28,35 @@ type ParticipantRepository interface {
 	// GetByEventID mencari semua participant di event tertentu
 	GetByEventID(ctx context.Context, eventID string) ([]*domain.Participant, error)
 
+	// List mencari participant di event dengan filter, sort dan pagination
+	// Return participant di halaman tersebut dan total participant yang cocok dengan filter
+	List(ctx context.Context, eventID string, filter *domain.ParticipantFilter) ([]*domain.Participant, int, error)
+
+	// Export memanggil fn untuk setiap participant yang cocok dengan filter, dibaca dari cursor database
+	// Limit dan Offset di filter tidak dipakai, error dari fn menghentikan export
+	Export(ctx context.Context, eventID string, filter *domain.ParticipantFilter, fn func(*domain.Participant) error) error
+
+	// AttributeKeys mencari semua key attribute participant di event, urut abjad
+	AttributeKeys(ctx context.Context, eventID string) ([]string, error)
+
 	// GetByQRToken mencari participant berdasarkan QR token (untuk check-in)
 	GetByQRToken(ctx context.Context, qrToken string) (*domain.Participant, error)
 
+	// Search mencari participant di event berdasarkan awalan nama, email atau phone
+	// phonePrefix awalan phone E.164 dari query, kosong berarti phone dicocokkan dengan query
+	Search(ctx context.Context, eventID, query, phonePrefix string, limit int) ([]*domain.Participant, error)
+
 	// CountByEventID menghitung jumlah participant di event
 	CountByEventID(ctx context.Context, eventID string) (int, error)
 
 	// CountCheckedInByEventID menghitung jumlah participant yang sudah check-in
 	CountCheckedInByEventID(ctx context.Context, eventID string) (int, error)
 
-	// UpdateCheckIn mengupdate status check-in participant
-	UpdateCheckIn(ctx context.Context, participantID int64) error
+	// CountInsideByEventID menghitung jumlah participant yang sedang berada di dalam venue
+	CountInsideByEventID(ctx context.Context, eventID string) (int, error)
+
+	// GetAttendanceStats menghitung statistik kehadiran event dalam satu query
+	GetAttendanceStats(ctx context.Context, eventID string) (*domain.AttendanceStats, error)
 
 	// MarkQRSent mengupdate status QR sudah dikirim
 	MarkQRSent(ctx context.Context, participantID int64) error
@@ -38,6 +64,23 @@ type ParticipantRepository interface {
 	// GetPendingQR mendapatkan participants yang belum dikirim QR code
 	GetPendingQR(ctx context.Context, eventID string) ([]*domain.Participant, error)
 
+	// GetNonE164Phones mengambil participant lama dengan phone yang belum dinormalisasi, per batch berdasarkan ID
+	// Phone yang sudah ditandai tidak valid dilewati
+	GetNonE164Phones(ctx context.Context, afterID int64, limit int) ([]*domain.Participant, error)
+
+	// ReplacePhones mengganti phone participant jika phone nya belum berubah sejak dibaca
+	ReplacePhones(ctx context.Context, changes []domain.PhoneChange) error
+
+	// MarkInvalidPhones menandai phone participant yang tidak bisa dinormalisasi supaya tidak diproses ulang
+	MarkInvalidPhones(ctx context.Context, participants []*domain.Participant) error
+
+	// Update mengubah name, email, phone, attributes dan status pengiriman QR participant
+	// Return domain.ErrParticipantAlreadyExists jika email baru sudah dipakai participant lain di event
+	Update(ctx context.Context, participant *domain.Participant) error
+
+	// Delete menghapus satu participant, log check-in tetap disimpan tanpa participant
+	Delete(ctx context.Context, id int64) error
+
 	// DeleteByEventID menghapus semua participant di event (cascade delete)
 	DeleteByEventID(ctx context.Context, eventID string) error
 }
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// ScannerLoginRequest request login scanner dengan slug event dan scanner PIN
+type ScannerLoginRequest struct {
+	Slug string `json:"slug" binding:"required"`
+	PIN  string `json:"pin" binding:"required,numeric,min=4,max=8"`
+}
+
+// ScannerLoginResponse response setelah scanner berhasil login
+type ScannerLoginResponse struct {
+	Token     string        `json:"token"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	Event     *ScannerEvent `json:"event"`
+}
+
+// ScannerEvent ringkasan event yang boleh dilihat oleh scanner
+type ScannerEvent struct {
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	Slug  string    `json:"slug"`
+	Date  time.Time `json:"date"`
+	Venue string    `json:"venue"`
+
+	// Public key untuk memvalidasi QR signed secara offline
+	TicketPublicKey string `json:"ticket_public_key,omitempty"`
+
+	// Jam check-in, nil berarti tidak dibatasi
+	CheckInOpensAt  *time.Time `json:"check_in_opens_at"`
+	CheckInClosesAt *time.Time `json:"check_in_closes_at"`
+}
+
+// ToScannerEvent mengubah event menjadi ringkasan untuk scanner
+func (e *Event) ToScannerEvent() *ScannerEvent {
+	return &ScannerEvent{
+		ID:    e.ID,
+		Name:  e.Name,
+		Slug:  e.Slug,
+		Date:  e.Date,
+		Venue: e.Venue,
+
+		TicketPublicKey: e.TicketPublicKey,
+		CheckInOpensAt:  e.CheckInOpensAt(),
+		CheckInClosesAt: e.CheckInClosesAt(),
+	}
+}
+
+// RotateScannerPINResponse response setelah scanner PIN diganti
+// PIN plain text hanya ditampilkan sekali di response ini
+type RotateScannerPINResponse struct {
+	ScannerPIN string `json:"scanner_pin"`
+}
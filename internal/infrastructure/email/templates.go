@@ -3,6 +3,7 @@ package email
 import (
 	"bytes"
 	"html/template"
+	"strings"
 	"time"
 
 	"github.com/fzndps/eventcheck/internal/domain"
@@ -16,6 +17,11 @@ type QREmailTemplate struct {
 	EventVenue      string
 	QRCodeBase64    string // Base64 encoded QR code (for inline)
 	UseCID          bool   // Use CID instead of base64 inline
+
+	// Attribute participant, bisa dipakai di template dengan {{index .Attributes "Company"}}
+	Attributes map[string]string
+	// Attribute sesuai schema event, ditampilkan di detail registrasi
+	AttributeValues []domain.AttributeValue
 }
 
 // BuildQRCodeEmail membuat HTML email dengan QR code
@@ -32,6 +38,8 @@ func BuildQRCodeEmail(participant *domain.Participant, event *domain.Event, qrCo
 		EventVenue:      event.Venue,
 		QRCodeBase64:    qrCodeBase64,
 		UseCID:          useCID,
+		Attributes:      participant.Attributes,
+		AttributeValues: event.AttributeSchema.Values(participant.Attributes),
 	}
 
 	tmpl := `
@@ -150,6 +158,17 @@ func BuildQRCodeEmail(participant *domain.Participant, event *domain.Event, qrCo
                 <div>{{.EventVenue}}</div>
             </div>
         </div>
+        {{if .AttributeValues}}
+        <div class="event-details">
+            <h3>📝 Registration Details</h3>
+            {{range .AttributeValues}}
+            <div class="detail-row">
+                <div class="detail-label">{{.Name}}:</div>
+                <div>{{.Value}}</div>
+            </div>
+            {{end}}
+        </div>
+        {{end}}
         
         <div class="instructions">
             <h4>📱 How to Check-In:</h4>
@@ -217,7 +236,7 @@ Event Details:
 - Event Name: ` + event.Name + `
 - Date & Time: ` + eventDate + `
 - Venue: ` + event.Venue + `
-
+` + plainTextAttributes(event.AttributeSchema.Values(participant.Attributes)) + `
 How to Check-In:
 1. Show your QR code at the registration desk
 2. Your attendance will be recorded instantly
@@ -231,6 +250,21 @@ EventCheck.in Team
 `
 }
 
+// plainTextAttributes menulis attribute participant untuk plain text email
+func plainTextAttributes(values []domain.AttributeValue) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\nRegistration Details:\n")
+	for _, v := range values {
+		b.WriteString("- " + v.Name + ": " + v.Value + "\n")
+	}
+
+	return b.String()
+}
+
 // BuildTestEmail membuat test email
 func BuildTestEmail(recipientName string) string {
 	return `
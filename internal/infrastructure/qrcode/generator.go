@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"fmt"
 
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/pkg/ticket"
 	qrcode "github.com/skip2/go-qrcode"
 )
 
@@ -37,6 +39,35 @@ func (g *Generator) GenerateQRCode(data string, size int) ([]byte, error) {
 	return pngBytes, nil
 }
 
+// TicketData menghasilkan isi QR tiket participant
+// Event dengan ticket key memakai payload signed yang bisa diverifikasi offline,
+// event lama tetap memakai QR token hex
+func (g *Generator) TicketData(event *domain.Event, participant *domain.Participant) (string, error) {
+	if !event.HasTicketKey() {
+		return participant.QRToken, nil
+	}
+
+	data, err := ticket.Sign(event.TicketPrivateKey, &ticket.Claims{
+		EventID:       event.ID,
+		ParticipantID: participant.ID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign ticket: %w", err)
+	}
+
+	return data, nil
+}
+
+// GenerateTicketQRCode menghasilkan QR tiket participant dalam format PNG bytes
+func (g *Generator) GenerateTicketQRCode(event *domain.Event, participant *domain.Participant, size int) ([]byte, error) {
+	data, err := g.TicketData(event, participant)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GenerateQRCode(data, size)
+}
+
 // Menghasilkan qr code dalam format base64 untuk embed langsung di html email
 func (g *Generator) GenerateQRCodeBase64(data string, size int) (string, error) {
 	pngBytes, err := g.GenerateQRCode(data, size)
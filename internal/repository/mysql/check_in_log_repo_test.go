@@ -0,0 +1,271 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/google/uuid"
+)
+
+func setupTestCheckInLogRepo(t *testing.T) (*checkInLogRepository, *participantRepository, *domain.Participant) {
+	participantRepo, eventID := setupTestParticipantRepo(t)
+
+	participant := &domain.Participant{
+		EventID: eventID,
+		Name:    "John Doe",
+		Email:   "john@example.com",
+		Phone:   "08123456789",
+		QRToken: uuid.New().String(),
+	}
+
+	if err := participantRepo.Create(context.Background(), participant); err != nil {
+		t.Fatal("Failed to create participant:", err)
+	}
+
+	return &checkInLogRepository{db: participantRepo.db}, participantRepo, participant
+}
+
+func newTestCheckInLog(p *domain.Participant, scannedAt time.Time) *domain.CheckInLog {
+	actor := domain.NewOrganizerActor(1)
+	scan := &domain.ScanInfo{QRToken: p.QRToken, DeviceID: "device-1", Gate: "Gate A"}
+
+	log := domain.NewCheckInLog(actor, p.EventID, domain.CheckInActionCheckIn, scan, scannedAt)
+	log.ParticipantID = &p.ID
+	log.Result = domain.CheckInResultAccepted
+
+	return log
+}
+
+func TestCheckInLogRepository_Record(t *testing.T) {
+	repo, participantRepo, participant := setupTestCheckInLogRepo(t)
+	defer cleanupTestEvent(t, participantRepo, participant.EventID)
+
+	scannedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	log := newTestCheckInLog(participant, scannedAt)
+
+	if err := repo.Record(context.Background(), log, 1); err != nil {
+		t.Fatal("Failed to record check-in log:", err)
+	}
+
+	if log.ID == 0 {
+		t.Error("Log ID should be set after record")
+	}
+	if log.Result != domain.CheckInResultAccepted {
+		t.Errorf("Expected result accepted, got %s", log.Result)
+	}
+
+	// Status participant harus ikut ter-update dari log
+	found, _ := participantRepo.GetByID(context.Background(), participant.ID)
+	if !found.CheckedIn {
+		t.Error("Expected checked_in to be true")
+	}
+	if found.CheckedInAt == nil || !found.CheckedInAt.Equal(scannedAt) {
+		t.Errorf("Expected checked_in_at %v, got %v", scannedAt, found.CheckedInAt)
+	}
+
+	t.Log("✅ Check-in log recorded and participant synced")
+}
+
+func TestCheckInLogRepository_Record_DuplicateKeepsEarliest(t *testing.T) {
+	repo, participantRepo, participant := setupTestCheckInLogRepo(t)
+	defer cleanupTestEvent(t, participantRepo, participant.EventID)
+
+	later := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	earlier := later.Add(-30 * time.Minute)
+
+	repo.Record(context.Background(), newTestCheckInLog(participant, later), 1)
+
+	// Device offline upload scan yang lebih awal
+	log := newTestCheckInLog(participant, earlier)
+	if err := repo.Record(context.Background(), log, 1); err != nil {
+		t.Fatal("Failed to record check-in log:", err)
+	}
+
+	if log.Result != domain.CheckInResultDuplicate {
+		t.Errorf("Expected result duplicate, got %s", log.Result)
+	}
+
+	found, _ := participantRepo.GetByID(context.Background(), participant.ID)
+	if found.CheckedInAt == nil || !found.CheckedInAt.Equal(earlier) {
+		t.Errorf("Expected checked_in_at %v, got %v", earlier, found.CheckedInAt)
+	}
+
+	t.Log("✅ Duplicate scan recorded and earliest scan time kept")
+}
+
+func TestCheckInLogRepository_Record_CheckOutAndReentry(t *testing.T) {
+	repo, participantRepo, participant := setupTestCheckInLogRepo(t)
+	defer cleanupTestEvent(t, participantRepo, participant.EventID)
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	// Event dengan batas 2 kali masuk
+	scans := []struct {
+		action   string
+		expected string
+	}{
+		{domain.CheckInActionCheckIn, domain.CheckInResultAccepted},
+		{domain.CheckInActionCheckOut, domain.CheckInResultAccepted},
+		{domain.CheckInActionCheckOut, domain.CheckInResultNotInside},
+		{domain.CheckInActionCheckIn, domain.CheckInResultAccepted},
+		{domain.CheckInActionCheckIn, domain.CheckInResultDuplicate},
+		{domain.CheckInActionCheckOut, domain.CheckInResultAccepted},
+		{domain.CheckInActionCheckIn, domain.CheckInResultEntryLimitReached},
+	}
+
+	for i, scan := range scans {
+		log := newTestCheckInLog(participant, start.Add(time.Duration(i)*time.Minute))
+		log.Action = scan.action
+
+		if err := repo.Record(context.Background(), log, 2); err != nil {
+			t.Fatal("Failed to record check-in log:", err)
+		}
+
+		if log.Result != scan.expected {
+			t.Errorf("Scan %d (%s): expected result %s, got %s", i, scan.action, scan.expected, log.Result)
+		}
+	}
+
+	found, _ := participantRepo.GetByID(context.Background(), participant.ID)
+	if found.IsInside {
+		t.Error("Expected is_inside to be false after check-out")
+	}
+	if found.EntryCount != 2 {
+		t.Errorf("Expected entry_count 2, got %d", found.EntryCount)
+	}
+	if !found.CheckedIn || found.CheckedInAt == nil || !found.CheckedInAt.Equal(start) {
+		t.Errorf("Expected checked_in_at to stay at first entry %v, got %v", start, found.CheckedInAt)
+	}
+
+	t.Log("✅ Check-out and re-entry limit working correctly")
+}
+
+func TestCheckInLogRepository_Record_Undo(t *testing.T) {
+	repo, participantRepo, participant := setupTestCheckInLogRepo(t)
+	defer cleanupTestEvent(t, participantRepo, participant.EventID)
+
+	checkIn := newTestCheckInLog(participant, time.Now().Add(-time.Minute))
+	repo.Record(context.Background(), checkIn, 1)
+
+	undo := newTestCheckInLog(participant, time.Now())
+	undo.Action = domain.CheckInActionUndo
+	undo.Method = domain.CheckInMethodManual
+	undo.Reason = "Test scan before doors open"
+
+	if err := repo.Record(context.Background(), undo, 1); err != nil {
+		t.Fatal("Failed to record undo:", err)
+	}
+
+	if undo.Result != domain.CheckInResultAccepted {
+		t.Errorf("Expected undo accepted, got %s", undo.Result)
+	}
+
+	found, _ := participantRepo.GetByID(context.Background(), participant.ID)
+	if found.CheckedIn || found.CheckedInAt != nil || found.IsInside || found.EntryCount != 0 {
+		t.Errorf("Expected check-in state to be reset, got %+v", found)
+	}
+
+	// Scan awal tetap ada di history dan ditandai reverted
+	logs, total, _ := repo.GetByParticipantID(context.Background(), participant.ID, 10, 0)
+	if total != 2 {
+		t.Fatalf("Expected 2 logs, got %d", total)
+	}
+
+	original := logs[1]
+	if original.ID != checkIn.ID || original.RevertedAt == nil || original.RevertedBy == nil || *original.RevertedBy != undo.ID {
+		t.Errorf("Expected original scan to be reverted by undo log, got %+v", original)
+	}
+	if logs[0].Reason != undo.Reason {
+		t.Errorf("Expected reason %q, got %q", undo.Reason, logs[0].Reason)
+	}
+
+	// Undo kedua tidak ada yang dibatalkan
+	again := newTestCheckInLog(participant, time.Now())
+	again.Action = domain.CheckInActionUndo
+	repo.Record(context.Background(), again, 1)
+
+	if again.Result != domain.CheckInResultNotCheckedIn {
+		t.Errorf("Expected not_checked_in, got %s", again.Result)
+	}
+
+	// Setelah undo participant bisa check-in lagi
+	recheck := newTestCheckInLog(participant, time.Now())
+	repo.Record(context.Background(), recheck, 1)
+
+	if recheck.Result != domain.CheckInResultAccepted {
+		t.Errorf("Expected check-in after undo to be accepted, got %s", recheck.Result)
+	}
+
+	t.Log("✅ Undo check-in working correctly")
+}
+
+func TestCheckInLogRepository_Record_UndoUsesScanTime(t *testing.T) {
+	repo, participantRepo, participant := setupTestCheckInLogRepo(t)
+	defer cleanupTestEvent(t, participantRepo, participant.EventID)
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	// Semua scan terjadi di detik yang sama
+	checkIn := newTestCheckInLog(participant, start.Add(100*time.Millisecond))
+	repo.Record(context.Background(), checkIn, 1)
+
+	checkOut := newTestCheckInLog(participant, start.Add(700*time.Millisecond))
+	checkOut.Action = domain.CheckInActionCheckOut
+	repo.Record(context.Background(), checkOut, 1)
+
+	// Undo offline yang terjadi di antara check-in dan check-out diupload terakhir
+	undo := newTestCheckInLog(participant, start.Add(400*time.Millisecond))
+	undo.Action = domain.CheckInActionUndo
+	if err := repo.Record(context.Background(), undo, 1); err != nil {
+		t.Fatal("Failed to record undo:", err)
+	}
+
+	logs, _, _ := repo.GetByParticipantID(context.Background(), participant.ID, 10, 0)
+	for _, log := range logs {
+		switch log.ID {
+		case checkIn.ID:
+			if log.RevertedAt == nil {
+				t.Error("Expected check-in before undo to be reverted")
+			}
+		case checkOut.ID:
+			if log.RevertedAt != nil {
+				t.Error("Expected check-out after undo to stay active")
+			}
+		}
+	}
+}
+
+func TestCheckInLogRepository_GetByEventID(t *testing.T) {
+	repo, participantRepo, participant := setupTestCheckInLogRepo(t)
+	defer cleanupTestEvent(t, participantRepo, participant.EventID)
+
+	repo.Record(context.Background(), newTestCheckInLog(participant, time.Now()), 1)
+
+	// Scan dengan token tidak dikenal tetap dicatat
+	unknown := domain.NewCheckInLog(domain.NewOrganizerActor(1), participant.EventID, domain.CheckInActionCheckIn,
+		&domain.ScanInfo{QRToken: "unknown-token"}, time.Now())
+	unknown.Result = domain.CheckInResultUnknownToken
+	repo.Record(context.Background(), unknown, 1)
+
+	logs, total, err := repo.GetByEventID(context.Background(), participant.EventID, 10, 0)
+	if err != nil {
+		t.Fatal("Failed to get check-in logs:", err)
+	}
+
+	if total != 2 || len(logs) != 2 {
+		t.Errorf("Expected 2 logs, got total=%d len=%d", total, len(logs))
+	}
+
+	logs, total, err = repo.GetByParticipantID(context.Background(), participant.ID, 10, 0)
+	if err != nil {
+		t.Fatal("Failed to get participant check-in logs:", err)
+	}
+
+	if total != 1 || len(logs) != 1 {
+		t.Errorf("Expected 1 participant log, got total=%d len=%d", total, len(logs))
+	}
+
+	t.Log("✅ Check-in logs listed by event and participant")
+}
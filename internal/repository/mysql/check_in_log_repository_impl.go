@@ -0,0 +1,288 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/domain/repository"
+)
+
+type checkInLogRepository struct {
+	db *sql.DB
+}
+
+func NewCheckInLogRepository(db *sql.DB) repository.CheckInLogRepository {
+	return &checkInLogRepository{
+		db: db,
+	}
+}
+
+// Record menyimpan log scan lalu menghitung ulang status check-in participant
+func (r *checkInLogRepository) Record(ctx context.Context, log *domain.CheckInLog, maxEntries int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if log.ParticipantID != nil {
+		// Kunci row participant supaya scan bersamaan diproses bergantian
+		var presence domain.ParticipantPresence
+		err := tx.QueryRowContext(ctx,
+			`SELECT is_inside, entry_count FROM participants WHERE id = ? FOR UPDATE`,
+			*log.ParticipantID,
+		).Scan(&presence.IsInside, &presence.EntryCount)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrNotFound
+			}
+			return err
+		}
+
+		if log.Result == domain.CheckInResultAccepted {
+			log.Result = domain.ResolveScanResult(log.Action, presence, maxEntries)
+		}
+	}
+
+	query := `
+		INSERT INTO check_in_logs (
+			event_id, participant_id, qr_token, action, method, result,
+			actor_type, actor_organizer_id, actor_session_id,
+			device_id, gate, reason, window_override, scanned_at, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		log.EventID,
+		log.ParticipantID,
+		nullString(log.QRToken),
+		log.Action,
+		log.Method,
+		log.Result,
+		log.ActorType,
+		log.ActorOrganizerID,
+		nullString(log.ActorSessionID),
+		nullString(log.DeviceID),
+		nullString(log.Gate),
+		nullString(log.Reason),
+		log.WindowOverride,
+		log.ScannedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create check-in log: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %v", err)
+	}
+	log.ID = id
+
+	if log.ParticipantID != nil {
+		// Undo membatalkan semua entry dan exit sebelumnya tanpa menghapus log nya
+		if log.Action == domain.CheckInActionUndo && log.Result == domain.CheckInResultAccepted {
+			if err := revertParticipantLogs(ctx, tx, *log.ParticipantID, log); err != nil {
+				return err
+			}
+		}
+
+		if err := syncParticipantCheckIn(ctx, tx, *log.ParticipantID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// revertParticipantLogs menandai entry dan exit participant yang masih aktif sebagai dibatalkan
+// Dibandingkan dengan waktu scan, bukan urutan upload, karena scan offline bisa diupload belakangan
+// Scan yang terjadi setelah undo tidak ikut dibatalkan walaupun diupload lebih dulu
+func revertParticipantLogs(ctx context.Context, tx *sql.Tx, participantID int64, undo *domain.CheckInLog) error {
+	query := `
+		UPDATE check_in_logs SET
+			reverted_at = NOW(),
+			reverted_by = ?
+		WHERE participant_id = ?
+			AND action IN (?, ?)
+			AND reverted_at IS NULL
+			AND scanned_at <= ?
+			AND id <> ?
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		undo.ID,
+		participantID,
+		domain.CheckInActionCheckIn,
+		domain.CheckInActionCheckOut,
+		undo.ScannedAt,
+		undo.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revert check-in logs: %v", err)
+	}
+
+	return nil
+}
+
+// syncParticipantCheckIn menghitung ulang status check-in participant dari log
+// Waktu check-in adalah scan valid paling awal, sehingga upload scan offline
+// yang lebih awal dari device lain otomatis memperbaiki waktu check-in
+// is_inside diambil dari entry atau exit terakhir yang diterima
+// Log yang sudah dibatalkan dengan undo tidak dihitung
+func syncParticipantCheckIn(ctx context.Context, tx *sql.Tx, participantID int64) error {
+	query := `
+		UPDATE participants SET
+			checked_in_at = (
+				SELECT MIN(l.scanned_at) FROM check_in_logs l
+				WHERE l.participant_id = ?
+					AND l.action = ?
+					AND l.result IN (?, ?)
+					AND l.reverted_at IS NULL
+			),
+			checked_in = checked_in_at IS NOT NULL,
+			entry_count = (
+				SELECT COUNT(*) FROM check_in_logs l
+				WHERE l.participant_id = ?
+					AND l.action = ?
+					AND l.result = ?
+					AND l.reverted_at IS NULL
+			),
+			is_inside = COALESCE((
+				SELECT l.action = ? FROM check_in_logs l
+				WHERE l.participant_id = ?
+					AND l.action IN (?, ?)
+					AND l.result = ?
+					AND l.reverted_at IS NULL
+				ORDER BY l.scanned_at DESC, l.id DESC
+				LIMIT 1
+			), FALSE)
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(ctx, query,
+		participantID,
+		domain.CheckInActionCheckIn,
+		domain.CheckInResultAccepted,
+		domain.CheckInResultDuplicate,
+		participantID,
+		domain.CheckInActionCheckIn,
+		domain.CheckInResultAccepted,
+		domain.CheckInActionCheckIn,
+		participantID,
+		domain.CheckInActionCheckIn,
+		domain.CheckInActionCheckOut,
+		domain.CheckInResultAccepted,
+		participantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to sync participant check-in: %v", err)
+	}
+
+	return nil
+}
+
+// GetByEventID mencari log di event tertentu dengan pagination
+func (r *checkInLogRepository) GetByEventID(ctx context.Context, eventID string, limit, offset int) ([]*domain.CheckInLog, int, error) {
+	return r.list(ctx, "event_id = ?", eventID, limit, offset)
+}
+
+// GetByParticipantID mencari log milik participant dengan pagination
+func (r *checkInLogRepository) GetByParticipantID(ctx context.Context, participantID int64, limit, offset int) ([]*domain.CheckInLog, int, error) {
+	return r.list(ctx, "participant_id = ?", participantID, limit, offset)
+}
+
+func (r *checkInLogRepository) list(ctx context.Context, where string, arg any, limit, offset int) ([]*domain.CheckInLog, int, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			id, event_id, participant_id, qr_token, action, method, result,
+			actor_type, actor_organizer_id, actor_session_id,
+			device_id, gate, reason, reverted_at, reverted_by, window_override, scanned_at, created_at
+		FROM check_in_logs
+		WHERE %s
+		ORDER BY scanned_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, where)
+
+	rows, err := r.db.QueryContext(ctx, query, arg, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get check-in logs: %v", err)
+	}
+	defer rows.Close()
+
+	logs := []*domain.CheckInLog{}
+	for rows.Next() {
+		log, err := scanCheckInLog(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM check_in_logs WHERE %s`, where)
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, arg).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+func scanCheckInLog(rows *sql.Rows) (*domain.CheckInLog, error) {
+	log := &domain.CheckInLog{}
+	var participantID, organizerID, revertedBy sql.NullInt64
+	var qrToken, sessionID, deviceID, gate, reason sql.NullString
+	var revertedAt sql.NullTime
+
+	err := rows.Scan(
+		&log.ID,
+		&log.EventID,
+		&participantID,
+		&qrToken,
+		&log.Action,
+		&log.Method,
+		&log.Result,
+		&log.ActorType,
+		&organizerID,
+		&sessionID,
+		&deviceID,
+		&gate,
+		&reason,
+		&revertedAt,
+		&revertedBy,
+		&log.WindowOverride,
+		&log.ScannedAt,
+		&log.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if participantID.Valid {
+		log.ParticipantID = &participantID.Int64
+	}
+	if organizerID.Valid {
+		log.ActorOrganizerID = &organizerID.Int64
+	}
+	if revertedAt.Valid {
+		log.RevertedAt = &revertedAt.Time
+	}
+	if revertedBy.Valid {
+		log.RevertedBy = &revertedBy.Int64
+	}
+
+	log.QRToken = qrToken.String
+	log.ActorSessionID = sessionID.String
+	log.DeviceID = deviceID.String
+	log.Gate = gate.String
+	log.Reason = reason.String
+
+	return log, nil
+}
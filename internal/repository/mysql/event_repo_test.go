@@ -2,6 +2,8 @@ package mysql
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -40,7 +42,7 @@ func TestEventRepository_Create(t *testing.T) {
 		ParticipantCount: 100,
 		TotalPrice:       450000,
 		PaymentStatus:    domain.PaymentStatusPending,
-		ScannerPIN:       "1234",
+		ScannerPINHash:   "1234",
 	}
 
 	err := repo.Create(context.Background(), event)
@@ -69,7 +71,9 @@ func TestEventRepository_GetByID(t *testing.T) {
 		ParticipantCount: 100,
 		TotalPrice:       450000,
 		PaymentStatus:    domain.PaymentStatusPending,
-		ScannerPIN:       "1234",
+		ScannerPINHash:   "1234",
+		TicketPublicKey:  "test-public-key",
+		TicketPrivateKey: "test-private-key",
 	}
 
 	repo.Create(context.Background(), event)
@@ -87,6 +91,12 @@ func TestEventRepository_GetByID(t *testing.T) {
 	if found.Name != event.Name {
 		t.Errorf("Expected name %s, got %s", event.Name, found.Name)
 	}
+	if found.TicketPublicKey != event.TicketPublicKey || found.TicketPrivateKey != event.TicketPrivateKey {
+		t.Error("Expected ticket keys to be stored")
+	}
+	if found.ReentryPolicy != domain.ReentryPolicySingle {
+		t.Errorf("Expected default reentry policy single, got %s", found.ReentryPolicy)
+	}
 
 	t.Log("✅ Event retrieved successfully")
 }
@@ -107,7 +117,7 @@ func TestEventRepository_GetBySlug(t *testing.T) {
 		ParticipantCount: 100,
 		TotalPrice:       450000,
 		PaymentStatus:    domain.PaymentStatusPending,
-		ScannerPIN:       "1234",
+		ScannerPINHash:   "1234",
 	}
 
 	repo.Create(context.Background(), event)
@@ -159,7 +169,7 @@ func TestEventRepository_GetByOrganizerID(t *testing.T) {
 			ParticipantCount: 100,
 			TotalPrice:       450000,
 			PaymentStatus:    domain.PaymentStatusPending,
-			ScannerPIN:       "1234",
+			ScannerPINHash:   "1234",
 		}
 		repo.Create(context.Background(), event)
 		eventIDs[i] = event.ID
@@ -202,7 +212,7 @@ func TestEventRepository_Update(t *testing.T) {
 		ParticipantCount: 100,
 		TotalPrice:       450000,
 		PaymentStatus:    domain.PaymentStatusPending,
-		ScannerPIN:       "1234",
+		ScannerPINHash:   "1234",
 	}
 
 	repo.Create(context.Background(), event)
@@ -244,7 +254,7 @@ func TestEventRepository_Delete(t *testing.T) {
 		ParticipantCount: 100,
 		TotalPrice:       450000,
 		PaymentStatus:    domain.PaymentStatusPending,
-		ScannerPIN:       "1234",
+		ScannerPINHash:   "1234",
 	}
 
 	repo.Create(context.Background(), event)
@@ -294,7 +304,7 @@ func TestEventRepository_IsOwnedBy(t *testing.T) {
 		ParticipantCount: 100,
 		TotalPrice:       450000,
 		PaymentStatus:    domain.PaymentStatusPending,
-		ScannerPIN:       "1234",
+		ScannerPINHash:   "1234",
 	}
 
 	repo.Create(context.Background(), event)
@@ -320,3 +330,237 @@ func TestEventRepository_IsOwnedBy(t *testing.T) {
 
 	t.Log("✅ Ownership check working correctly")
 }
+
+func TestEventRepository_UpdateScannerPIN(t *testing.T) {
+	repo := setupTestEventRepo(t)
+	defer repo.db.Close()
+
+	event := &domain.Event{
+		ID:               uuid.New().String(),
+		OrganizerID:      1,
+		Name:             "Test Event",
+		Slug:             "test-event-" + time.Now().Format("20060102150405"),
+		Date:             time.Now().Add(24 * time.Hour),
+		Venue:            "Test Venue",
+		ParticipantCount: 100,
+		TotalPrice:       450000,
+		PaymentStatus:    domain.PaymentStatusPending,
+		ScannerPINHash:   "1234",
+	}
+
+	repo.Create(context.Background(), event)
+	defer repo.Delete(context.Background(), event.ID)
+
+	err := repo.UpdateScannerPIN(context.Background(), event.ID, "new-hash")
+	if err != nil {
+		t.Fatal("Failed to update scanner PIN:", err)
+	}
+
+	found, err := repo.GetByID(context.Background(), event.ID)
+	if err != nil {
+		t.Fatal("Failed to get event:", err)
+	}
+
+	if found.ScannerPINHash != "new-hash" {
+		t.Errorf("Expected scanner PIN hash 'new-hash', got '%s'", found.ScannerPINHash)
+	}
+	if found.ScannerPINVersion != 2 {
+		t.Errorf("Expected scanner PIN version 2, got %d", found.ScannerPINVersion)
+	}
+
+	t.Log("✅ Scanner PIN rotated successfully")
+}
+
+func TestEventRepository_ReplacePlainScannerPIN(t *testing.T) {
+	repo := setupTestEventRepo(t)
+	defer repo.db.Close()
+
+	event := &domain.Event{
+		ID:               uuid.New().String(),
+		OrganizerID:      1,
+		Name:             "Test Event",
+		Slug:             "test-event-" + time.Now().Format("20060102150405"),
+		Date:             time.Now().Add(24 * time.Hour),
+		Venue:            "Test Venue",
+		ParticipantCount: 100,
+		TotalPrice:       450000,
+		PaymentStatus:    domain.PaymentStatusPending,
+		ScannerPINHash:   "1234",
+	}
+
+	repo.Create(context.Background(), event)
+	defer repo.Delete(context.Background(), event.ID)
+
+	// Event test lain juga memakai PIN plain text, jadi semua batch dibaca
+	plainPINs := func() map[string]string {
+		pins := make(map[string]string)
+		afterID := ""
+		for {
+			events, err := repo.GetPlainScannerPINs(context.Background(), afterID, 2)
+			if err != nil {
+				t.Fatal("Failed to get plain scanner PINs:", err)
+			}
+			for _, e := range events {
+				pins[e.ID] = e.ScannerPINHash
+			}
+			if len(events) < 2 {
+				return pins
+			}
+			afterID = events[len(events)-1].ID
+		}
+	}
+
+	if pins := plainPINs(); pins[event.ID] != "1234" {
+		t.Fatalf("Expected plain PIN '1234', got '%s'", pins[event.ID])
+	}
+
+	// PIN yang sudah berubah tidak ditimpa
+	if err := repo.ReplacePlainScannerPIN(context.Background(), event.ID, "9999", "$2a$stale"); err != nil {
+		t.Fatal("Failed to replace scanner PIN:", err)
+	}
+	if err := repo.ReplacePlainScannerPIN(context.Background(), event.ID, "1234", "$2a$hash"); err != nil {
+		t.Fatal("Failed to replace scanner PIN:", err)
+	}
+
+	found, err := repo.GetByID(context.Background(), event.ID)
+	if err != nil {
+		t.Fatal("Failed to get event:", err)
+	}
+	if found.ScannerPINHash != "$2a$hash" {
+		t.Errorf("Expected scanner PIN hash '$2a$hash', got '%s'", found.ScannerPINHash)
+	}
+	if found.ScannerPINVersion != 1 {
+		t.Errorf("Expected scanner PIN version to stay 1, got %d", found.ScannerPINVersion)
+	}
+
+	if _, ok := plainPINs()[event.ID]; ok {
+		t.Error("Expected hashed PIN to be excluded")
+	}
+}
+
+func TestEventRepository_UpdateAttributeSchema(t *testing.T) {
+	repo := setupTestEventRepo(t)
+	defer repo.db.Close()
+
+	event := &domain.Event{
+		ID:               uuid.New().String(),
+		OrganizerID:      1,
+		Name:             "Test Event",
+		Slug:             "test-event-" + time.Now().Format("20060102150405"),
+		Date:             time.Now().Add(24 * time.Hour),
+		Venue:            "Test Venue",
+		ParticipantCount: 100,
+		TotalPrice:       450000,
+		PaymentStatus:    domain.PaymentStatusPending,
+		ScannerPINHash:   "1234",
+	}
+
+	repo.Create(context.Background(), event)
+	defer repo.Delete(context.Background(), event.ID)
+
+	schema := domain.AttributeSchema{
+		{Name: "Company", Type: domain.AttributeTypeText, Required: true},
+		{Name: "T-Shirt Size", Type: domain.AttributeTypeText, AllowedValues: []string{"S", "M", "L"}},
+	}
+
+	if err := repo.UpdateAttributeSchema(context.Background(), event.ID, schema); err != nil {
+		t.Fatal("Failed to update attribute schema:", err)
+	}
+
+	found, err := repo.GetByID(context.Background(), event.ID)
+	if err != nil {
+		t.Fatal("Failed to get event:", err)
+	}
+
+	if len(found.AttributeSchema) != 2 || found.AttributeSchema[1].AllowedValues[2] != "L" {
+		t.Errorf("Expected attribute schema to be stored, got %+v", found.AttributeSchema)
+	}
+
+	// Schema kosong menghapus schema
+	if err := repo.UpdateAttributeSchema(context.Background(), event.ID, nil); err != nil {
+		t.Fatal("Failed to clear attribute schema:", err)
+	}
+
+	found, err = repo.GetByID(context.Background(), event.ID)
+	if err != nil {
+		t.Fatal("Failed to get event:", err)
+	}
+
+	if found.AttributeSchema != nil {
+		t.Errorf("Expected attribute schema to be cleared, got %+v", found.AttributeSchema)
+	}
+
+	t.Log("✅ Attribute schema updated successfully")
+}
+
+func TestEventRepository_AddCapacity(t *testing.T) {
+	repo := setupTestEventRepo(t)
+	defer repo.db.Close()
+
+	event := &domain.Event{
+		ID:               uuid.New().String(),
+		OrganizerID:      1,
+		Name:             "Test Event",
+		Slug:             "test-event-" + time.Now().Format("20060102150405"),
+		Date:             time.Now().Add(24 * time.Hour),
+		Venue:            "Test Venue",
+		ParticipantCount: 100,
+		TotalPrice:       domain.CalculatePrice(100),
+		PaymentStatus:    domain.PaymentStatusVerified,
+		ScannerPINHash:   "1234",
+	}
+
+	repo.Create(context.Background(), event)
+	defer repo.Delete(context.Background(), event.ID)
+
+	// Pembelian bersamaan tidak boleh saling menimpa
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.AddCapacity(context.Background(), event.ID, 10); err != nil {
+				t.Error("Failed to add capacity:", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	found, err := repo.GetByID(context.Background(), event.ID)
+	if err != nil {
+		t.Fatal("Failed to get event:", err)
+	}
+
+	// Kuota yang sudah dibayar tetap berlaku sampai selisih harga diverifikasi
+	if found.ParticipantCount != 100 || found.PendingParticipants != 50 {
+		t.Errorf("Expected 100 paid and 50 pending participants, got %d and %d", found.ParticipantCount, found.PendingParticipants)
+	}
+	if found.PaymentStatus != domain.PaymentStatusVerified {
+		t.Errorf("Expected payment status to stay verified, got %s", found.PaymentStatus)
+	}
+
+	// Selisih harga sama seperti membeli seluruh kuota tambahan sekaligus
+	_, expectedTotal, expectedPrice := domain.CapacityUpgrade(100, event.TotalPrice, 50)
+	if found.TotalPrice != event.TotalPrice || found.PendingPrice != expectedPrice {
+		t.Errorf("Expected total price %d with %d pending, got %d with %d", event.TotalPrice, expectedPrice, found.TotalPrice, found.PendingPrice)
+	}
+
+	if _, err := repo.ApplyPendingCapacity(context.Background(), event.ID, expectedPrice-1); !errors.Is(err, domain.ErrPendingCapacityNotFound) {
+		t.Errorf("Expected ErrPendingCapacityNotFound for wrong amount, got %v", err)
+	}
+
+	if _, err := repo.ApplyPendingCapacity(context.Background(), event.ID, expectedPrice); err != nil {
+		t.Fatal("Failed to apply pending capacity:", err)
+	}
+
+	found, _ = repo.GetByID(context.Background(), event.ID)
+	if found.ParticipantCount != 150 || found.TotalPrice != expectedTotal || found.PendingParticipants != 0 || found.PendingPrice != 0 {
+		t.Errorf("Expected 150 participants with total price %d, got %+v", expectedTotal, found)
+	}
+
+	if _, err := repo.AddCapacity(context.Background(), uuid.New().String(), 10); !errors.Is(err, domain.ErrEventNotFound) {
+		t.Errorf("Expected ErrEventNotFound, got %v", err)
+	}
+
+	t.Log("✅ Capacity added atomically")
+}
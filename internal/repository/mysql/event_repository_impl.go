@@ -3,7 +3,9 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 
 	"github.com/fzndps/eventcheck/internal/domain"
@@ -22,11 +24,18 @@ func NewEventRepository(db *sql.DB) repository.EventRepository {
 
 // Create menyimpan event baru ke database
 func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error {
+	// Event tanpa re-entry policy diperlakukan sebagai single entry
+	if event.ReentryPolicy == "" {
+		event.ReentryPolicy = domain.ReentryPolicySingle
+	}
+
 	query := `INSERT INTO events (
 			id, organizer_id, name, slug, date, venue, 
 			participant_count, total_price, payment_status, 
-			payment_proof_url, scanner_pin, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())`
+			payment_proof_url, scanner_pin, reentry_policy, max_entries,
+			ticket_public_key, ticket_private_key,
+			check_in_opens_before, check_in_closes_after, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())`
 
 	_, err := r.db.ExecContext(ctx, query,
 		event.ID,
@@ -39,7 +48,13 @@ func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error
 		event.TotalPrice,
 		event.PaymentStatus,
 		event.PaymentProofURL,
-		event.ScannerPIN,
+		event.ScannerPINHash,
+		event.ReentryPolicy,
+		event.MaxEntries,
+		nullString(event.TicketPublicKey),
+		nullString(event.TicketPrivateKey),
+		event.CheckInOpensBefore,
+		event.CheckInClosesAfter,
 	)
 
 	if err != nil {
@@ -57,13 +72,16 @@ func (r *eventRepository) Create(ctx context.Context, event *domain.Event) error
 func (r *eventRepository) GetByID(ctx context.Context, id string) (*domain.Event, error) {
 	query := `
 		SELECT id, organizer_id, name, slug, date, venue, 
-		participant_count, total_price, payment_status, 
-		payment_proof_url, scanner_pin, created_at
+		participant_count, total_price, pending_participants, pending_price, payment_status, 
+		payment_proof_url, scanner_pin, scanner_pin_version, reentry_policy, max_entries,
+		ticket_public_key, ticket_private_key,
+		check_in_opens_before, check_in_closes_after, attribute_schema, created_at
 		FROM events WHERE id = ?
 	`
 
 	event := &domain.Event{}
-	var paymentProofURL sql.NullString
+	var paymentProofURL, ticketPublicKey, ticketPrivateKey sql.NullString
+	var attributeSchema []byte
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&event.ID,
@@ -74,9 +92,19 @@ func (r *eventRepository) GetByID(ctx context.Context, id string) (*domain.Event
 		&event.Venue,
 		&event.ParticipantCount,
 		&event.TotalPrice,
+		&event.PendingParticipants,
+		&event.PendingPrice,
 		&event.PaymentStatus,
 		&paymentProofURL,
-		&event.ScannerPIN,
+		&event.ScannerPINHash,
+		&event.ScannerPINVersion,
+		&event.ReentryPolicy,
+		&event.MaxEntries,
+		&ticketPublicKey,
+		&ticketPrivateKey,
+		&event.CheckInOpensBefore,
+		&event.CheckInClosesAfter,
+		&attributeSchema,
 		&event.CreatedAt,
 	)
 
@@ -92,6 +120,13 @@ func (r *eventRepository) GetByID(ctx context.Context, id string) (*domain.Event
 		event.PaymentProofURL = paymentProofURL.String
 	}
 
+	event.TicketPublicKey = ticketPublicKey.String
+	event.TicketPrivateKey = ticketPrivateKey.String
+
+	if err := decodeAttributeSchema(attributeSchema, event); err != nil {
+		return nil, err
+	}
+
 	return event, nil
 }
 
@@ -99,13 +134,16 @@ func (r *eventRepository) GetByID(ctx context.Context, id string) (*domain.Event
 func (r *eventRepository) GetBySlug(ctx context.Context, slug string) (*domain.Event, error) {
 	query := `
 		SELECT id, organizer_id, name, slug, date, venue, 
-		participant_count, total_price, payment_status, 
-		payment_proof_url, scanner_pin, created_at
+		participant_count, total_price, pending_participants, pending_price, payment_status, 
+		payment_proof_url, scanner_pin, scanner_pin_version, reentry_policy, max_entries,
+		ticket_public_key, ticket_private_key,
+		check_in_opens_before, check_in_closes_after, attribute_schema, created_at
 		FROM events WHERE slug = ?
 	`
 
 	event := &domain.Event{}
-	var paymentProofURL sql.NullString
+	var paymentProofURL, ticketPublicKey, ticketPrivateKey sql.NullString
+	var attributeSchema []byte
 
 	err := r.db.QueryRowContext(ctx, query, slug).Scan(
 		&event.ID,
@@ -116,9 +154,19 @@ func (r *eventRepository) GetBySlug(ctx context.Context, slug string) (*domain.E
 		&event.Venue,
 		&event.ParticipantCount,
 		&event.TotalPrice,
+		&event.PendingParticipants,
+		&event.PendingPrice,
 		&event.PaymentStatus,
 		&paymentProofURL,
-		&event.ScannerPIN,
+		&event.ScannerPINHash,
+		&event.ScannerPINVersion,
+		&event.ReentryPolicy,
+		&event.MaxEntries,
+		&ticketPublicKey,
+		&ticketPrivateKey,
+		&event.CheckInOpensBefore,
+		&event.CheckInClosesAfter,
+		&attributeSchema,
 		&event.CreatedAt,
 	)
 
@@ -134,6 +182,13 @@ func (r *eventRepository) GetBySlug(ctx context.Context, slug string) (*domain.E
 		event.PaymentProofURL = paymentProofURL.String
 	}
 
+	event.TicketPublicKey = ticketPublicKey.String
+	event.TicketPrivateKey = ticketPrivateKey.String
+
+	if err := decodeAttributeSchema(attributeSchema, event); err != nil {
+		return nil, err
+	}
+
 	return event, nil
 }
 
@@ -143,8 +198,10 @@ func (r *eventRepository) GetByOrganizerID(ctx context.Context, organizerID int6
 	query := `
 		SELECT
 			id, organizer_id, name, slug, date, venue, 
-			participant_count, total_price, payment_status, 
-			payment_proof_url, scanner_pin, created_at
+			participant_count, total_price, pending_participants, pending_price, payment_status, 
+			payment_proof_url, scanner_pin, scanner_pin_version, reentry_policy, max_entries,
+			ticket_public_key, ticket_private_key,
+		check_in_opens_before, check_in_closes_after, attribute_schema, created_at
 		FROM events 
 		WHERE organizer_id = ?
 		ORDER BY created_at DESC
@@ -159,7 +216,8 @@ func (r *eventRepository) GetByOrganizerID(ctx context.Context, organizerID int6
 	var events []*domain.Event
 	for rows.Next() {
 		event := &domain.Event{}
-		var paymentProofURL sql.NullString
+		var paymentProofURL, ticketPublicKey, ticketPrivateKey sql.NullString
+		var attributeSchema []byte
 
 		err := rows.Scan(
 			&event.ID,
@@ -170,9 +228,19 @@ func (r *eventRepository) GetByOrganizerID(ctx context.Context, organizerID int6
 			&event.Venue,
 			&event.ParticipantCount,
 			&event.TotalPrice,
+			&event.PendingParticipants,
+			&event.PendingPrice,
 			&event.PaymentStatus,
 			&paymentProofURL,
-			&event.ScannerPIN,
+			&event.ScannerPINHash,
+			&event.ScannerPINVersion,
+			&event.ReentryPolicy,
+			&event.MaxEntries,
+			&ticketPublicKey,
+			&ticketPrivateKey,
+			&event.CheckInOpensBefore,
+			&event.CheckInClosesAfter,
+			&attributeSchema,
 			&event.CreatedAt,
 		)
 
@@ -184,6 +252,13 @@ func (r *eventRepository) GetByOrganizerID(ctx context.Context, organizerID int6
 			event.PaymentProofURL = paymentProofURL.String
 		}
 
+		event.TicketPublicKey = ticketPublicKey.String
+		event.TicketPrivateKey = ticketPrivateKey.String
+
+		if err := decodeAttributeSchema(attributeSchema, event); err != nil {
+			return nil, 0, err
+		}
+
 		events = append(events, event)
 	}
 
@@ -198,6 +273,7 @@ func (r *eventRepository) GetByOrganizerID(ctx context.Context, organizerID int6
 }
 
 // Update mengupdate data event
+// participant_count dan total_price hanya diubah lewat AddCapacity dan ApplyPendingCapacity
 func (r *eventRepository) Update(ctx context.Context, event *domain.Event) error {
 	query := `
 		UPDATE events SET
@@ -205,8 +281,10 @@ func (r *eventRepository) Update(ctx context.Context, event *domain.Event) error
 			slug = ?,
 			date = ?,
 			venue = ?,
-			participant_count = ?,
-			total_price = ?
+			reentry_policy = ?,
+			max_entries = ?,
+			check_in_opens_before = ?,
+			check_in_closes_after = ?
 		WHERE id = ?
 	`
 
@@ -215,8 +293,10 @@ func (r *eventRepository) Update(ctx context.Context, event *domain.Event) error
 		event.Slug,
 		event.Date,
 		event.Venue,
-		event.ParticipantCount,
-		event.TotalPrice,
+		event.ReentryPolicy,
+		event.MaxEntries,
+		event.CheckInOpensBefore,
+		event.CheckInClosesAfter,
 		event.ID,
 	)
 
@@ -240,6 +320,209 @@ func (r *eventRepository) Update(ctx context.Context, event *domain.Event) error
 	return nil
 }
 
+// AddCapacity menambah kuota participant event yang menunggu pembayaran
+// participant_count dan payment_status tidak berubah sampai selisih harga diverifikasi,
+// kuota tambahan tanpa selisih harga langsung aktif
+// Row event dikunci supaya pembelian kuota bersamaan tidak saling menimpa
+func (r *eventRepository) AddCapacity(ctx context.Context, eventID string, additional int) (*domain.BuyCapacityResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var count, total, pending int
+	err = tx.QueryRowContext(ctx,
+		`SELECT participant_count, total_price, pending_participants FROM events WHERE id = ? FOR UPDATE`,
+		eventID,
+	).Scan(&count, &total, &pending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	pending, pendingPrice := domain.PendingCapacity(count, total, pending, additional)
+
+	res := &domain.BuyCapacityResponse{
+		ParticipantCount:    count,
+		PendingParticipants: pending,
+		PreviousTotalPrice:  total,
+		TotalPrice:          total + pendingPrice,
+		AdditionalPrice:     pendingPrice,
+	}
+
+	if pendingPrice == 0 {
+		res.ParticipantCount += pending
+		res.PendingParticipants = 0
+	}
+
+	query := `
+		UPDATE events SET
+			participant_count = ?,
+			pending_participants = ?,
+			pending_price = ?
+		WHERE id = ?
+	`
+
+	if _, err := tx.ExecContext(ctx, query, res.ParticipantCount, res.PendingParticipants, pendingPrice, eventID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ApplyPendingCapacity mengaktifkan kuota tambahan setelah pembayaran selisih harga diverifikasi
+// paidPrice harus sama dengan pending_price supaya pembelian setelah pembayaran tidak ikut aktif
+func (r *eventRepository) ApplyPendingCapacity(ctx context.Context, eventID string, paidPrice int) (*domain.BuyCapacityResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var count, total, pending, pendingPrice int
+	err = tx.QueryRowContext(ctx,
+		`SELECT participant_count, total_price, pending_participants, pending_price FROM events WHERE id = ? FOR UPDATE`,
+		eventID,
+	).Scan(&count, &total, &pending, &pendingPrice)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	if pending == 0 || pendingPrice != paidPrice {
+		return nil, domain.ErrPendingCapacityNotFound
+	}
+
+	query := `
+		UPDATE events SET
+			participant_count = participant_count + pending_participants,
+			total_price = total_price + pending_price,
+			pending_participants = 0,
+			pending_price = 0
+		WHERE id = ?
+	`
+
+	if _, err := tx.ExecContext(ctx, query, eventID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &domain.BuyCapacityResponse{
+		ParticipantCount:   count + pending,
+		PreviousTotalPrice: total,
+		TotalPrice:         total + pendingPrice,
+	}, nil
+}
+
+// UpdateScannerPIN mengganti hash scanner PIN dan menaikkan scanner_pin_version
+func (r *eventRepository) UpdateScannerPIN(ctx context.Context, eventID, pinHash string) error {
+	query := `
+		UPDATE events SET
+			scanner_pin = ?,
+			scanner_pin_version = scanner_pin_version + 1
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, pinHash, eventID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	return nil
+}
+
+// GetPlainScannerPINs mengambil event yang scanner PIN nya masih plain text, urut berdasarkan ID
+// Hanya ID dan ScannerPINHash yang diisi, hash bcrypt selalu diawali "$2"
+func (r *eventRepository) GetPlainScannerPINs(ctx context.Context, afterID string, limit int) ([]*domain.Event, error) {
+	query := `
+		SELECT id, scanner_pin FROM events
+		WHERE scanner_pin NOT LIKE '$2%' AND id > ?
+		ORDER BY id
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.Event
+	for rows.Next() {
+		event := &domain.Event{}
+		if err := rows.Scan(&event.ID, &event.ScannerPINHash); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// ReplacePlainScannerPIN mengganti PIN plain text dengan hash nya tanpa menaikkan scanner_pin_version
+// PIN nya sama, jadi scanner session yang sudah ada tetap berlaku
+// Tidak mengubah apa pun jika PIN sudah diganti sejak dibaca
+func (r *eventRepository) ReplacePlainScannerPIN(ctx context.Context, eventID, pin, pinHash string) error {
+	query := `UPDATE events SET scanner_pin = ? WHERE id = ? AND scanner_pin = ?`
+
+	_, err := r.db.ExecContext(ctx, query, pinHash, eventID, pin)
+	return err
+}
+
+// UpdateAttributeSchema mengganti schema attribute participant event
+func (r *eventRepository) UpdateAttributeSchema(ctx context.Context, eventID string, schema domain.AttributeSchema) error {
+	query := `UPDATE events SET attribute_schema = ? WHERE id = ?`
+
+	// Schema kosong disimpan sebagai NULL
+	var data []byte
+	if len(schema) > 0 {
+		encoded, err := json.Marshal(schema)
+		if err != nil {
+			return fmt.Errorf("failed to encode attribute schema: %w", err)
+		}
+		data = encoded
+	}
+
+	result, err := r.db.ExecContext(ctx, query, data, eventID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	return nil
+}
+
 // Delete menghapus event (dan cascade delete participants)
 func (r *eventRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM events WHERE id = ?`
@@ -261,6 +544,19 @@ func (r *eventRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// decodeAttributeSchema membaca kolom attribute_schema, NULL berarti event tanpa schema
+func decodeAttributeSchema(data []byte, event *domain.Event) error {
+	if data == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &event.AttributeSchema); err != nil {
+		return fmt.Errorf("failed to decode attribute schema: %w", err)
+	}
+
+	return nil
+}
+
 // IsOwnedBy mengecek apakah event dimiliki oleh organizer
 func (r *eventRepository) IsOwnedBy(ctx context.Context, eventID string, organizerID int64) (bool, error) {
 	query := `SELECT COUNT(*) FROM events WHERE id = ? AND organizer_id = ?`
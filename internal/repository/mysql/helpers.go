@@ -1,6 +1,9 @@
 package mysql
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/go-sql-driver/mysql"
@@ -21,6 +24,60 @@ func isDuplicateKeyError(err error) bool {
 	return strings.Contains(err.Error(), "Duplicate entry") || strings.Contains(err.Error(), "duplicate key")
 }
 
+// nullString mengubah string kosong menjadi NULL di database
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// encodeJSON mengubah map menjadi JSON, map kosong disimpan sebagai NULL
+func encodeJSON(m map[string]string) (sql.NullString, error) {
+	if len(m) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// jsonMap membaca kolom JSON ke map saat Scan, NULL menjadi map nil
+type jsonMap struct {
+	m *map[string]string
+}
+
+func (j jsonMap) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*j.m = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, j.m)
+	case string:
+		return json.Unmarshal([]byte(v), j.m)
+	default:
+		return fmt.Errorf("unsupported type %T for JSON column", src)
+	}
+}
+
+// escapeLike meng-escape karakter wildcard LIKE dari input user
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(s)
+}
+
+// placeholders membuat daftar placeholder "?, ?, ?" untuk klausa IN
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// rowPlaceholders membuat placeholder n row constructor dengan size kolom, misalnya (?, ?), (?, ?)
+func rowPlaceholders(n, size int) string {
+	return strings.TrimSuffix(strings.Repeat("("+placeholders(size)+"), ", n), ", ")
+}
+
 // // Mengecek error apakah foreign key constraint violation
 // func isForeignKeyError(err error) bool {
 // 	if err == nil {
@@ -0,0 +1,147 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestImportJobRepository(t *testing.T) {
+	participantRepo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, participantRepo, eventID)
+
+	repo := NewImportJobRepository(participantRepo.db)
+	ctx := context.Background()
+
+	job := &domain.ImportJob{
+		ID:          uuid.New().String(),
+		EventID:     eventID,
+		OrganizerID: 1,
+		Status:      domain.ImportJobQueued,
+		Options:     &domain.ImportOptions{Mode: domain.ImportModeSkip, Format: domain.ImportFormatCSV},
+		TotalRows:   2,
+		CreatedAt:   time.Now().Add(-time.Hour), // paling lama supaya diambil lebih dulu
+	}
+	content := []byte("name,email,phone\nJohn,john@example.com,0812\n")
+
+	if err := repo.Create(ctx, job, content); err != nil {
+		t.Fatal("Failed to create import job:", err)
+	}
+
+	claimed, err := repo.ClaimNext(ctx)
+	if err != nil {
+		t.Fatal("Failed to claim import job:", err)
+	}
+
+	if claimed.ID != job.ID || claimed.Status != domain.ImportJobRunning || claimed.StartedAt == nil {
+		t.Fatalf("Expected claimed running job %s, got %+v", job.ID, claimed)
+	}
+
+	if claimed.Options.Mode != domain.ImportModeSkip {
+		t.Errorf("Expected options to be stored, got %+v", claimed.Options)
+	}
+
+	stored, err := repo.GetContent(ctx, job.ID)
+	if err != nil || string(stored) != string(content) {
+		t.Errorf("Expected stored content, got %q (%v)", stored, err)
+	}
+
+	claimed.ProcessedRows = 1
+	claimed.Created = 1
+	if err := repo.UpdateProgress(ctx, claimed); err != nil {
+		t.Fatal("Failed to update progress:", err)
+	}
+
+	claimed.Headers = []string{"name", "email", "phone"}
+	claimed.AddErrors(&domain.ImportRowError{
+		Row:       3,
+		ErrorCode: domain.ImportErrorRequired,
+		Message:   "name is required",
+		Record:    []string{"", "budi@gmail.com", "08123456789"},
+	})
+	claimed.AddWarnings(&domain.ImportRowWarning{Row: 4, WarningCode: domain.ImportWarningEmailTypo, Suggestion: "budi@gmail.com"})
+	claimed.Finish(nil, time.Now())
+	if err := repo.Finish(ctx, claimed); err != nil {
+		t.Fatal("Failed to finish import job:", err)
+	}
+
+	finished, err := repo.GetByID(ctx, job.ID)
+	if err != nil {
+		t.Fatal("Failed to get import job:", err)
+	}
+
+	if finished.Status != domain.ImportJobCompleted || finished.Created != 1 || finished.Failed != 1 {
+		t.Errorf("Unexpected finished job: %+v", finished)
+	}
+
+	if len(finished.Errors) != 1 || finished.Errors[0].Row != 3 {
+		t.Errorf("Expected error report to be stored, got %v", finished.Errors)
+	}
+
+	// Header dan isi row disimpan untuk CSV row yang ditolak
+	if len(finished.Headers) != 3 || len(finished.Errors) != 1 || len(finished.Errors[0].Record) != 3 ||
+		finished.Errors[0].Record[1] != "budi@gmail.com" {
+		t.Errorf("Expected headers and rejected record to be stored, got %v and %v", finished.Headers, finished.Errors)
+	}
+
+	if len(finished.Warnings) != 1 || finished.Warnings[0].Suggestion != "budi@gmail.com" {
+		t.Errorf("Expected warning report to be stored, got %v", finished.Warnings)
+	}
+
+	// Isi file dihapus setelah job selesai
+	if stored, _ := repo.GetContent(ctx, job.ID); stored != nil {
+		t.Errorf("Expected content to be cleared, got %d bytes", len(stored))
+	}
+
+	if _, err := repo.GetByID(ctx, uuid.New().String()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestImportJobRepository_FailStale(t *testing.T) {
+	participantRepo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, participantRepo, eventID)
+
+	repo := NewImportJobRepository(participantRepo.db)
+	ctx := context.Background()
+
+	job := &domain.ImportJob{
+		ID:          uuid.New().String(),
+		EventID:     eventID,
+		OrganizerID: 1,
+		Status:      domain.ImportJobRunning,
+		Options:     &domain.ImportOptions{Mode: domain.ImportModeReject, Format: domain.ImportFormatCSV},
+		CreatedAt:   time.Now(),
+	}
+	if err := repo.Create(ctx, job, []byte("name,email,phone\n")); err != nil {
+		t.Fatal("Failed to create import job:", err)
+	}
+
+	if err := repo.FailStale(ctx, time.Now().Add(time.Minute), "interrupted"); err != nil {
+		t.Fatal("Failed to fail stale jobs:", err)
+	}
+
+	failed, _ := repo.GetByID(ctx, job.ID)
+	if failed.Status != domain.ImportJobFailed || failed.ErrorMessage != "interrupted" {
+		t.Errorf("Expected stale job to be failed, got %+v", failed)
+	}
+
+	// Worker yang masih memproses job tidak boleh menimpa status failed
+	if err := repo.UpdateProgress(ctx, job); !errors.Is(err, domain.ErrImportJobNotRunning) {
+		t.Errorf("Expected ErrImportJobNotRunning on progress, got %v", err)
+	}
+
+	job.Finish(nil, time.Now())
+	if err := repo.Finish(ctx, job); !errors.Is(err, domain.ErrImportJobNotRunning) {
+		t.Errorf("Expected ErrImportJobNotRunning on finish, got %v", err)
+	}
+
+	failed, _ = repo.GetByID(ctx, job.ID)
+	if failed.Status != domain.ImportJobFailed {
+		t.Errorf("Expected job to stay failed, got %s", failed.Status)
+	}
+}
@@ -0,0 +1,330 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/domain/repository"
+)
+
+type importJobRepository struct {
+	db *sql.DB
+}
+
+func NewImportJobRepository(db *sql.DB) repository.ImportJobRepository {
+	return &importJobRepository{
+		db: db,
+	}
+}
+
+const importJobColumns = `
+	id, event_id, organizer_id, status, options, headers,
+	total_rows, processed_rows, created_count, updated_count, skipped_count, failed_count,
+	errors, warnings, error_message, created_at, started_at, finished_at
+`
+
+func (r *importJobRepository) Create(ctx context.Context, job *domain.ImportJob, content []byte) error {
+	options, err := json.Marshal(job.Options)
+	if err != nil {
+		return fmt.Errorf("failed to encode import options: %w", err)
+	}
+
+	query := `
+		INSERT INTO import_jobs (id, event_id, organizer_id, status, options, content, total_rows, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		job.ID,
+		job.EventID,
+		job.OrganizerID,
+		job.Status,
+		options,
+		content,
+		job.TotalRows,
+		job.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) GetByID(ctx context.Context, id string) (*domain.ImportJob, error) {
+	query := `SELECT ` + importJobColumns + ` FROM import_jobs WHERE id = ?`
+
+	job, err := scanImportJob(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *importJobRepository) GetContent(ctx context.Context, id string) ([]byte, error) {
+	var content []byte
+	err := r.db.QueryRowContext(ctx, `SELECT content FROM import_jobs WHERE id = ?`, id).Scan(&content)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job content: %w", err)
+	}
+
+	return content, nil
+}
+
+func (r *importJobRepository) ClaimNext(ctx context.Context) (*domain.ImportJob, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	// SKIP LOCKED supaya worker lain tidak menunggu job yang sedang diambil
+	query := `
+		SELECT ` + importJobColumns + `
+		FROM import_jobs
+		WHERE status = ?
+		ORDER BY created_at ASC
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	job, err := scanImportJob(tx.QueryRowContext(ctx, query, domain.ImportJobQueued))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim import job: %w", err)
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx,
+		`UPDATE import_jobs SET status = ?, started_at = ? WHERE id = ?`,
+		domain.ImportJobRunning, now, job.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim import job: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	job.Status = domain.ImportJobRunning
+	job.StartedAt = &now
+
+	return job, nil
+}
+
+func (r *importJobRepository) UpdateProgress(ctx context.Context, job *domain.ImportJob) error {
+	query := `
+		UPDATE import_jobs
+		SET total_rows = ?, processed_rows = ?, created_count = ?, updated_count = ?,
+			skipped_count = ?, failed_count = ?, updated_at = NOW()
+		WHERE id = ? AND status = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		job.TotalRows,
+		job.ProcessedRows,
+		job.Created,
+		job.Updated,
+		job.Skipped,
+		job.Failed,
+		job.ID,
+		domain.ImportJobRunning,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update import job progress: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	// Row yang tidak berubah (progress dan updated_at sama dalam detik yang sama) juga 0 rows affected,
+	// jadi status dicek ulang untuk membedakannya dengan job yang sudah tidak running
+	var status string
+	if err := r.db.QueryRowContext(ctx, `SELECT status FROM import_jobs WHERE id = ?`, job.ID).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrImportJobNotRunning
+		}
+		return fmt.Errorf("failed to get import job status: %w", err)
+	}
+
+	if status != domain.ImportJobRunning {
+		return domain.ErrImportJobNotRunning
+	}
+
+	return nil
+}
+
+// storedRowError row error yang disimpan bersama isi row asli untuk CSV row yang ditolak
+type storedRowError struct {
+	*domain.ImportRowError
+	Record []string `json:"record,omitempty"`
+}
+
+func (r *importJobRepository) Finish(ctx context.Context, job *domain.ImportJob) error {
+	stored := make([]storedRowError, 0, len(job.Errors))
+	for _, rowErr := range job.Errors {
+		stored = append(stored, storedRowError{ImportRowError: rowErr, Record: rowErr.Record})
+	}
+
+	rowErrors, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode import errors: %w", err)
+	}
+
+	headers, err := json.Marshal(job.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode import headers: %w", err)
+	}
+
+	warnings, err := json.Marshal(job.Warnings)
+	if err != nil {
+		return fmt.Errorf("failed to encode import warnings: %w", err)
+	}
+
+	query := `
+		UPDATE import_jobs
+		SET status = ?, headers = ?, total_rows = ?, processed_rows = ?, created_count = ?, updated_count = ?,
+			skipped_count = ?, failed_count = ?, errors = ?, warnings = ?, error_message = ?, finished_at = ?,
+			content = NULL
+		WHERE id = ? AND status = ?
+	`
+
+	// Status selalu berubah dari running, jadi 0 rows affected berarti job sudah tidak running
+	result, err := r.db.ExecContext(ctx, query,
+		job.Status,
+		headers,
+		job.TotalRows,
+		job.ProcessedRows,
+		job.Created,
+		job.Updated,
+		job.Skipped,
+		job.Failed,
+		rowErrors,
+		warnings,
+		nullString(job.ErrorMessage),
+		job.FinishedAt,
+		job.ID,
+		domain.ImportJobRunning,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to finish import job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrImportJobNotRunning
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) FailStale(ctx context.Context, before time.Time, message string) error {
+	query := `
+		UPDATE import_jobs
+		SET status = ?, error_message = ?, finished_at = NOW(), content = NULL
+		WHERE status = ? AND updated_at < ?
+	`
+
+	_, err := r.db.ExecContext(ctx, query, domain.ImportJobFailed, message, domain.ImportJobRunning, before)
+	if err != nil {
+		return fmt.Errorf("failed to fail stale import jobs: %w", err)
+	}
+
+	return nil
+}
+
+func scanImportJob(row *sql.Row) (*domain.ImportJob, error) {
+	job := &domain.ImportJob{}
+	var options, headers []byte
+	var rowErrors, warnings []byte
+	var errorMessage sql.NullString
+	var startedAt, finishedAt sql.NullTime
+
+	err := row.Scan(
+		&job.ID,
+		&job.EventID,
+		&job.OrganizerID,
+		&job.Status,
+		&options,
+		&headers,
+		&job.TotalRows,
+		&job.ProcessedRows,
+		&job.Created,
+		&job.Updated,
+		&job.Skipped,
+		&job.Failed,
+		&rowErrors,
+		&warnings,
+		&errorMessage,
+		&job.CreatedAt,
+		&startedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(options, &job.Options); err != nil {
+		return nil, fmt.Errorf("failed to decode import options: %w", err)
+	}
+
+	if headers != nil {
+		if err := json.Unmarshal(headers, &job.Headers); err != nil {
+			return nil, fmt.Errorf("failed to decode import headers: %w", err)
+		}
+	}
+
+	if rowErrors != nil {
+		var stored []storedRowError
+		if err := json.Unmarshal(rowErrors, &stored); err != nil {
+			return nil, fmt.Errorf("failed to decode import errors: %w", err)
+		}
+
+		for _, rowErr := range stored {
+			rowErr.ImportRowError.Record = rowErr.Record
+			job.Errors = append(job.Errors, rowErr.ImportRowError)
+		}
+	}
+
+	if warnings != nil {
+		if err := json.Unmarshal(warnings, &job.Warnings); err != nil {
+			return nil, fmt.Errorf("failed to decode import warnings: %w", err)
+		}
+	}
+
+	job.ErrorMessage = errorMessage.String
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return job, nil
+}
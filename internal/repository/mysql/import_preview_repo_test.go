@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestImportPreviewRepository(t *testing.T) {
+	participantRepo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, participantRepo, eventID)
+
+	repo := NewImportPreviewRepository(participantRepo.db)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	expired := &domain.ImportPreview{
+		Token:       uuid.New().String(),
+		EventID:     eventID,
+		OrganizerID: 1,
+		Options:     &domain.ImportOptions{Mode: domain.ImportModeReject, Format: domain.ImportFormatCSV},
+		Content:     []byte("name,email,phone\n"),
+		ExpiresAt:   now.Add(-time.Minute),
+		CreatedAt:   now.Add(-domain.ImportPreviewTTL),
+	}
+	if err := repo.Create(ctx, expired); err != nil {
+		t.Fatal("Failed to create expired preview:", err)
+	}
+
+	preview := &domain.ImportPreview{
+		Token:       uuid.New().String(),
+		EventID:     eventID,
+		OrganizerID: 1,
+		Options: &domain.ImportOptions{
+			Mode:    domain.ImportModeUpdate,
+			Mapping: domain.ColumnMapping{"peserta": domain.ParticipantFieldName},
+			Format:  domain.ImportFormatXLSX,
+			Sheet:   "Peserta",
+		},
+		Content:   []byte{0x50, 0x4B, 0x03, 0x04},
+		ExpiresAt: now.Add(domain.ImportPreviewTTL),
+		CreatedAt: now,
+	}
+	if err := repo.Create(ctx, preview); err != nil {
+		t.Fatal("Failed to create preview:", err)
+	}
+
+	// Preview yang expired dihapus saat preview baru dibuat
+	if _, err := repo.GetByToken(ctx, expired.Token); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected expired preview to be deleted, got %v", err)
+	}
+
+	found, err := repo.GetByToken(ctx, preview.Token)
+	if err != nil {
+		t.Fatal("Failed to get preview:", err)
+	}
+
+	if found.EventID != eventID || string(found.Content) != string(preview.Content) {
+		t.Errorf("Unexpected preview: %+v", found)
+	}
+
+	if found.Options.Mode != domain.ImportModeUpdate || found.Options.Sheet != "Peserta" ||
+		found.Options.Mapping["peserta"] != domain.ParticipantFieldName {
+		t.Errorf("Unexpected options: %+v", found.Options)
+	}
+
+	// Token yang sudah expired tidak bisa dipakai
+	if err := repo.Claim(ctx, preview.Token, preview.ExpiresAt); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for expired token, got %v", err)
+	}
+
+	if err := repo.Claim(ctx, preview.Token, now); err != nil {
+		t.Fatal("Failed to claim preview:", err)
+	}
+
+	// Token tidak bisa dipakai request lain selama import berjalan
+	if err := repo.Claim(ctx, preview.Token, now); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound when claiming twice, got %v", err)
+	}
+
+	// Token bisa dipakai lagi setelah import gagal
+	if err := repo.Release(ctx, preview.Token); err != nil {
+		t.Fatal("Failed to release preview:", err)
+	}
+	if err := repo.Claim(ctx, preview.Token, now); err != nil {
+		t.Fatal("Failed to claim released preview:", err)
+	}
+
+	if err := repo.Delete(ctx, preview.Token); err != nil {
+		t.Fatal("Failed to delete preview:", err)
+	}
+
+	if _, err := repo.GetByToken(ctx, preview.Token); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/domain/repository"
+)
+
+type importPreviewRepository struct {
+	db *sql.DB
+}
+
+func NewImportPreviewRepository(db *sql.DB) repository.ImportPreviewRepository {
+	return &importPreviewRepository{
+		db: db,
+	}
+}
+
+func (r *importPreviewRepository) Create(ctx context.Context, preview *domain.ImportPreview) error {
+	options, err := json.Marshal(preview.Options)
+	if err != nil {
+		return fmt.Errorf("failed to encode import options: %w", err)
+	}
+
+	// Preview yang tidak pernah dikonfirmasi dibersihkan setiap ada preview baru
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM import_previews WHERE expires_at <= ?`, preview.CreatedAt); err != nil {
+		return fmt.Errorf("failed to delete expired import previews: %w", err)
+	}
+
+	query := `
+		INSERT INTO import_previews (token, event_id, organizer_id, options, content, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		preview.Token,
+		preview.EventID,
+		preview.OrganizerID,
+		options,
+		preview.Content,
+		preview.ExpiresAt,
+		preview.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create import preview: %w", err)
+	}
+
+	return nil
+}
+
+func (r *importPreviewRepository) GetByToken(ctx context.Context, token string) (*domain.ImportPreview, error) {
+	query := `
+		SELECT token, event_id, organizer_id, options, content, expires_at, created_at
+		FROM import_previews
+		WHERE token = ?
+	`
+
+	preview := &domain.ImportPreview{}
+	var options []byte
+
+	err := r.db.QueryRowContext(ctx, query, token).Scan(
+		&preview.Token,
+		&preview.EventID,
+		&preview.OrganizerID,
+		&options,
+		&preview.Content,
+		&preview.ExpiresAt,
+		&preview.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get import preview: %w", err)
+	}
+
+	if err := json.Unmarshal(options, &preview.Options); err != nil {
+		return nil, fmt.Errorf("failed to decode import options: %w", err)
+	}
+
+	return preview, nil
+}
+
+func (r *importPreviewRepository) Claim(ctx context.Context, token string, now time.Time) error {
+	// Hanya satu request yang bisa meng-claim row, request lain mendapat 0 rows affected
+	query := `
+		UPDATE import_previews
+		SET claimed_at = ?
+		WHERE token = ? AND expires_at > ? AND claimed_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now, token, now)
+	if err != nil {
+		return fmt.Errorf("failed to claim import preview: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *importPreviewRepository) Release(ctx context.Context, token string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE import_previews SET claimed_at = NULL WHERE token = ?`, token); err != nil {
+		return fmt.Errorf("failed to release import preview: %w", err)
+	}
+
+	return nil
+}
+
+func (r *importPreviewRepository) Delete(ctx context.Context, token string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM import_previews WHERE token = ?`, token); err != nil {
+		return fmt.Errorf("failed to delete import preview: %w", err)
+	}
+
+	return nil
+}
@@ -84,3 +84,33 @@ func (r *organizerRepositoryImpl) GetByID(ctx context.Context, id int64) (*domai
 
 	return organizer, nil
 }
+
+func (r *organizerRepositoryImpl) GetImportMapping(ctx context.Context, id int64) (domain.ColumnMapping, error) {
+	query := "SELECT import_mapping FROM organizers WHERE id = ?"
+
+	var mapping map[string]string
+	err := r.db.QueryRowContext(ctx, query, id).Scan(jsonMap{&mapping})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get import mapping: %w", err)
+	}
+
+	return domain.ColumnMapping(mapping), nil
+}
+
+func (r *organizerRepositoryImpl) UpdateImportMapping(ctx context.Context, id int64, mapping domain.ColumnMapping) error {
+	query := "UPDATE organizers SET import_mapping = ? WHERE id = ?"
+
+	data, err := encodeJSON(mapping)
+	if err != nil {
+		return fmt.Errorf("failed to encode import mapping: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, data, id); err != nil {
+		return fmt.Errorf("failed to update import mapping: %w", err)
+	}
+
+	return nil
+}
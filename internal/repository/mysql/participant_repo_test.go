@@ -2,10 +2,10 @@ package mysql
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"testing"
+	"time"
 
 	"github.com/fzndps/eventcheck/config"
 	"github.com/fzndps/eventcheck/internal/domain"
@@ -51,6 +51,14 @@ func cleanupTestEvent(t *testing.T, repo *participantRepository, eventID string)
 	repo.db.Close()
 }
 
+// checkInTestParticipant mencatat check-in lewat check_in_logs, sama seperti scan dari aplikasi
+func checkInTestParticipant(t *testing.T, repo *participantRepository, p *domain.Participant) {
+	logRepo := &checkInLogRepository{db: repo.db}
+	if err := logRepo.Record(context.Background(), newTestCheckInLog(p, time.Now()), 1); err != nil {
+		t.Fatal("Failed to record check-in:", err)
+	}
+}
+
 func TestParticipantRepository_Create(t *testing.T) {
 	repo, eventID := setupTestParticipantRepo(t)
 	defer cleanupTestEvent(t, repo, eventID)
@@ -75,44 +83,192 @@ func TestParticipantRepository_Create(t *testing.T) {
 	t.Log("✅ Participant created successfully with ID:", participant.ID)
 }
 
-func TestParticipantRepository_BulkCreate(t *testing.T) {
+func TestParticipantRepository_Create_WithAttributes(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	participant := &domain.Participant{
+		EventID:    eventID,
+		Name:       "John Doe",
+		Email:      "john@example.com",
+		Phone:      "08123456789",
+		QRToken:    uuid.New().String(),
+		Attributes: map[string]string{"Instansi": "Universitas Indonesia", "Ukuran Kaos": "L"},
+	}
+
+	if err := repo.Create(context.Background(), participant); err != nil {
+		t.Fatal("Failed to create participant:", err)
+	}
+
+	found, err := repo.GetByID(context.Background(), participant.ID)
+	if err != nil {
+		t.Fatal("Failed to get participant:", err)
+	}
+
+	if len(found.Attributes) != 2 || found.Attributes["Instansi"] != "Universitas Indonesia" {
+		t.Errorf("Expected attributes to be stored, got %v", found.Attributes)
+	}
+}
+
+func TestParticipantRepository_Import(t *testing.T) {
 	repo, eventID := setupTestParticipantRepo(t)
 	defer cleanupTestEvent(t, repo, eventID)
 
-	// Create 100 participants
-	participants := make([]*domain.Participant, 100)
-	for i := 0; i < 100; i++ {
-		participants[i] = &domain.Participant{
+	existing := &domain.Participant{
+		EventID: eventID,
+		Name:    "John Doe",
+		Email:   "john@example.com",
+		Phone:   "08123456789",
+		QRToken: uuid.New().String(),
+	}
+	repo.Create(context.Background(), existing)
+
+	newImport := func() []*domain.Participant {
+		return []*domain.Participant{
+			{EventID: eventID, Name: "John Smith", Email: "JOHN@example.com", Phone: "08123456789", QRToken: uuid.New().String()},
+			{EventID: eventID, Name: "Jane Smith", Email: "jane@example.com", Phone: "08987654321", QRToken: uuid.New().String()},
+		}
+	}
+
+	tests := []struct {
+		mode     string
+		expected domain.ImportResult
+	}{
+		{domain.ImportModeReject, domain.ImportResult{Created: 1, Rejected: []int{0}}},
+		{domain.ImportModeSkip, domain.ImportResult{Skipped: 2}},
+		{domain.ImportModeUpdate, domain.ImportResult{Updated: 1, Skipped: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			result, err := repo.Import(context.Background(), eventID, newImport(), tt.mode, 100)
+			if err != nil {
+				t.Fatal("Failed to import participants:", err)
+			}
+
+			if result.Created != tt.expected.Created || result.Updated != tt.expected.Updated ||
+				result.Skipped != tt.expected.Skipped || len(result.Rejected) != len(tt.expected.Rejected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *result)
+			}
+		})
+	}
+
+	count, _ := repo.CountByEventID(context.Background(), eventID)
+	if count != 2 {
+		t.Errorf("Expected 2 participants without duplicates, got %d", count)
+	}
+
+	// Participant baru melebihi quota ditolak seluruhnya
+	extra := []*domain.Participant{
+		{EventID: eventID, Name: "Bob", Email: "bob@example.com", Phone: "08111", QRToken: uuid.New().String()},
+	}
+	_, err := repo.Import(context.Background(), eventID, extra, domain.ImportModeReject, 2)
+	if !errors.Is(err, domain.ErrParticipantQuotaExceeded) {
+		t.Errorf("Expected ErrParticipantQuotaExceeded, got %v", err)
+	}
+
+	updated, _ := repo.GetByID(context.Background(), existing.ID)
+	if updated.Name != "John Smith" {
+		t.Errorf("Expected name to be updated, got %s", updated.Name)
+	}
+}
+
+func TestParticipantRepository_Import_ManyRows(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	// Lebih dari satu batch insert
+	participants := make([]*domain.Participant, 0, 2500)
+	for i := 0; i < 2500; i++ {
+		participants = append(participants, &domain.Participant{
 			EventID: eventID,
-			Name:    "Participant " + string(rune('0'+i%10)),
-			Email:   "user" + string(rune('0'+i%10)) + "@example.com",
-			Phone:   "0812345678" + string(rune('0'+i%10)),
+			Name:    fmt.Sprintf("Participant %d", i),
+			Email:   fmt.Sprintf("participant%d@example.com", i),
+			Phone:   fmt.Sprintf("08%09d", i),
 			QRToken: uuid.New().String(),
-		}
+		})
 	}
 
-	err := repo.BulkCreate(context.Background(), participants)
+	result, err := repo.Import(context.Background(), eventID, participants, domain.ImportModeReject, 3000)
 	if err != nil {
-		t.Fatal("Failed to bulk create participants:", err)
+		t.Fatal("Failed to import participants:", err)
 	}
 
-	// Verify count
-	count, err := repo.CountByEventID(context.Background(), eventID)
-	if err != nil {
-		t.Fatal("Failed to count participants:", err)
+	count, _ := repo.CountByEventID(context.Background(), eventID)
+	if result.Created != 2500 || count != 2500 {
+		t.Errorf("Expected 2500 participants, got created %d and count %d", result.Created, count)
 	}
+}
 
-	b, err := json.MarshalIndent(participants, "", "  ")
+func TestParticipantRepository_PreviewImport(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	existing := &domain.Participant{
+		EventID: eventID,
+		Name:    "John Doe",
+		Email:   "john@example.com",
+		Phone:   "08123456789",
+		QRToken: uuid.New().String(),
+	}
+	repo.Create(context.Background(), existing)
+
+	participants := []*domain.Participant{
+		{EventID: eventID, Name: "John Smith", Email: "john@example.com", Phone: "08123456789"},
+		{EventID: eventID, Name: "Jane Smith", Email: "jane@example.com", Phone: "08987654321"},
+	}
+
+	result, err := repo.PreviewImport(context.Background(), eventID, participants, domain.ImportModeUpdate)
 	if err != nil {
-		log.Fatal(err)
+		t.Fatal("Failed to preview import:", err)
+	}
+
+	if result.Created != 1 || result.Updated != 1 || result.Registered != 1 {
+		t.Errorf("Expected 1 created, 1 updated and 1 registered, got %+v", *result)
+	}
+
+	// Preview tidak mengubah data
+	count, _ := repo.CountByEventID(context.Background(), eventID)
+	if count != 1 {
+		t.Errorf("Expected 1 participant after preview, got %d", count)
 	}
-	fmt.Println(string(b))
 
-	if count != 100 {
-		t.Errorf("Expected 100 participants, got %d", count)
+	unchanged, _ := repo.GetByID(context.Background(), existing.ID)
+	if unchanged.Name != "John Doe" {
+		t.Errorf("Expected name to stay John Doe, got %s", unchanged.Name)
+	}
+}
+
+func TestParticipantRepository_PreviewImport_OnlyLoadsCandidates(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	for i := 0; i < 3; i++ {
+		repo.Create(context.Background(), &domain.Participant{
+			EventID: eventID,
+			Name:    fmt.Sprintf("Participant %d", i),
+			Email:   fmt.Sprintf("participant%d@example.com", i),
+			Phone:   fmt.Sprintf("0812000000%d", i),
+			QRToken: uuid.New().String(),
+		})
 	}
 
-	t.Log("✅ Bulk created 100 participants successfully")
+	// Email berbeda huruf besar kecil dan phone yang sama tetap dianggap duplikat
+	participants := []*domain.Participant{
+		{EventID: eventID, Name: "Participant 0", Email: "PARTICIPANT0@example.com", Phone: "08999"},
+		{EventID: eventID, Name: "Participant 1", Email: "other@example.com", Phone: "08120000001"},
+		{EventID: eventID, Name: "New", Email: "new@example.com", Phone: "08777"},
+	}
+
+	result, err := repo.PreviewImport(context.Background(), eventID, participants, domain.ImportModeSkip)
+	if err != nil {
+		t.Fatal("Failed to preview import:", err)
+	}
+
+	// Registered tetap jumlah seluruh participant event walaupun hanya kandidat duplikat yang dibaca
+	if result.Created != 1 || result.Skipped != 2 || result.Registered != 3 {
+		t.Errorf("Expected 1 created, 2 skipped and 3 registered, got %+v", *result)
+	}
 }
 
 func TestParticipantRepository_GetByEventID(t *testing.T) {
@@ -144,6 +300,142 @@ func TestParticipantRepository_GetByEventID(t *testing.T) {
 	t.Log("✅ Retrieved participants by event ID successfully")
 }
 
+func TestParticipantRepository_List(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	names := []string{"Citra Lestari", "Ahmad Budi", "Budi Santoso", "Dewi Anggraini"}
+	participants := make([]*domain.Participant, 0, len(names))
+	for i, name := range names {
+		p := &domain.Participant{
+			EventID: eventID,
+			Name:    name,
+			Email:   fmt.Sprintf("user%d@example.com", i),
+			Phone:   "08123456789",
+			QRToken: uuid.New().String(),
+		}
+		repo.Create(context.Background(), p)
+		participants = append(participants, p)
+	}
+
+	checkInTestParticipant(t, repo, participants[0])
+	repo.MarkQRSent(context.Background(), participants[1].ID)
+
+	checkedIn := true
+	qrSent := true
+
+	tests := []struct {
+		name          string
+		filter        *domain.ParticipantFilter
+		expectedTotal int
+		expectedFirst string
+	}{
+		{"All sorted by name", &domain.ParticipantFilter{SortBy: domain.ParticipantSortName, Limit: 10}, 4, "Ahmad Budi"},
+		{"Sorted by name desc", &domain.ParticipantFilter{SortBy: domain.ParticipantSortName, SortDesc: true, Limit: 10}, 4, "Dewi Anggraini"},
+		{"Second page", &domain.ParticipantFilter{SortBy: domain.ParticipantSortName, Limit: 2, Offset: 2}, 4, "Citra Lestari"},
+		{"Checked in only", &domain.ParticipantFilter{CheckedIn: &checkedIn, Limit: 10}, 1, "Citra Lestari"},
+		{"QR sent only", &domain.ParticipantFilter{QRSent: &qrSent, Limit: 10}, 1, "Ahmad Budi"},
+		{"Search by word", &domain.ParticipantFilter{Query: "budi", SortBy: domain.ParticipantSortName, Limit: 10}, 2, "Ahmad Budi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, total, err := repo.List(context.Background(), eventID, tt.filter)
+			if err != nil {
+				t.Fatal("Failed to list participants:", err)
+			}
+
+			if total != tt.expectedTotal {
+				t.Errorf("Expected total %d, got %d", tt.expectedTotal, total)
+			}
+
+			if len(found) == 0 || found[0].Name != tt.expectedFirst {
+				t.Errorf("Expected first participant %s, got %v", tt.expectedFirst, found)
+			}
+		})
+	}
+}
+
+func TestParticipantRepository_Export(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	names := []string{"Citra Lestari", "Ahmad Budi", "Budi Santoso"}
+	participants := make([]*domain.Participant, 0, len(names))
+	for i, name := range names {
+		p := &domain.Participant{
+			EventID: eventID,
+			Name:    name,
+			Email:   fmt.Sprintf("user%d@example.com", i),
+			Phone:   "08123456789",
+			QRToken: uuid.New().String(),
+		}
+		repo.Create(context.Background(), p)
+		participants = append(participants, p)
+	}
+
+	checkInTestParticipant(t, repo, participants[0])
+
+	// Peserta yang tidak hadir, Limit tidak dipakai saat export
+	checkedIn := false
+	filter := &domain.ParticipantFilter{CheckedIn: &checkedIn, SortBy: domain.ParticipantSortName, Limit: 1}
+
+	var exported []string
+	err := repo.Export(context.Background(), eventID, filter, func(p *domain.Participant) error {
+		exported = append(exported, p.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Failed to export participants:", err)
+	}
+
+	if len(exported) != 2 || exported[0] != "Ahmad Budi" || exported[1] != "Budi Santoso" {
+		t.Errorf("Expected no-show participants sorted by name, got %v", exported)
+	}
+
+	// Error dari fn menghentikan export
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.Export(context.Background(), eventID, &domain.ParticipantFilter{}, func(p *domain.Participant) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Expected export to stop after first error, got %v after %d calls", err, calls)
+	}
+}
+
+func TestParticipantRepository_AttributeKeys(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	attributes := []map[string]string{
+		{"Kota": "Bandung", "Instansi": "ACME"},
+		{"Instansi": "Globex", "Ukuran Kaos": "L"},
+		nil,
+	}
+	for i, attrs := range attributes {
+		repo.Create(context.Background(), &domain.Participant{
+			EventID:    eventID,
+			Name:       fmt.Sprintf("User %d", i),
+			Email:      fmt.Sprintf("user%d@example.com", i),
+			Phone:      "08123456789",
+			QRToken:    uuid.New().String(),
+			Attributes: attrs,
+		})
+	}
+
+	keys, err := repo.AttributeKeys(context.Background(), eventID)
+	if err != nil {
+		t.Fatal("Failed to get attribute keys:", err)
+	}
+
+	expected := []string{"Instansi", "Kota", "Ukuran Kaos"}
+	if fmt.Sprint(keys) != fmt.Sprint(expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+}
+
 func TestParticipantRepository_GetByQRToken(t *testing.T) {
 	repo, eventID := setupTestParticipantRepo(t)
 	defer cleanupTestEvent(t, repo, eventID)
@@ -176,6 +468,57 @@ func TestParticipantRepository_GetByQRToken(t *testing.T) {
 	t.Log("✅ Retrieved participant by QR token successfully")
 }
 
+func TestParticipantRepository_Search(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	participants := []*domain.Participant{
+		{Name: "Ahmad Budi", Email: "ahmad@example.com", Phone: "08123450001"},
+		{Name: "Budiman", Email: "budiman@example.com", Phone: "08123450002"},
+		{Name: "Citra", Email: "citra@example.com", Phone: "08567890003"},
+		{Name: "Dewi_Lestari", Email: "dewi@example.com", Phone: "08567890004"},
+		{Name: "Eko", Email: "eko@example.com", Phone: "+6281299990005"},
+	}
+
+	for _, p := range participants {
+		p.EventID = eventID
+		p.QRToken = uuid.New().String()
+		repo.Create(context.Background(), p)
+	}
+
+	tests := []struct {
+		query       string
+		phonePrefix string
+		expected    int
+	}{
+		{"budi", "", 2},             // awalan nama dan awalan kata kedua
+		{"citra@", "", 1},           // awalan email
+		{"08567", "", 2},            // awalan phone
+		{"0812999", "+62812999", 1}, // awalan phone E.164
+		{"_", "", 0},                // wildcard di-escape
+		{"lestari", "", 0},          // bukan awalan kata
+	}
+
+	for _, tt := range tests {
+		found, err := repo.Search(context.Background(), eventID, tt.query, tt.phonePrefix, 10)
+		if err != nil {
+			t.Fatal("Failed to search participants:", err)
+		}
+
+		if len(found) != tt.expected {
+			t.Errorf("Search(%q): expected %d participants, got %d", tt.query, tt.expected, len(found))
+		}
+	}
+
+	// Limit hasil pencarian
+	found, _ := repo.Search(context.Background(), eventID, "08", "", 3)
+	if len(found) != 3 {
+		t.Errorf("Expected 3 participants with limit, got %d", len(found))
+	}
+
+	t.Log("✅ Search participants working correctly")
+}
+
 func TestParticipantRepository_CountByEventID(t *testing.T) {
 	repo, eventID := setupTestParticipantRepo(t)
 	defer cleanupTestEvent(t, repo, eventID)
@@ -185,7 +528,7 @@ func TestParticipantRepository_CountByEventID(t *testing.T) {
 		p := &domain.Participant{
 			EventID: eventID,
 			Name:    "User",
-			Email:   "user@example.com",
+			Email:   fmt.Sprintf("user%d@example.com", i),
 			Phone:   "08123456789",
 			QRToken: uuid.New().String(),
 		}
@@ -204,36 +547,16 @@ func TestParticipantRepository_CountByEventID(t *testing.T) {
 	t.Log("✅ Count participants working correctly")
 }
 
-func TestParticipantRepository_UpdateCheckIn(t *testing.T) {
+func TestParticipantRepository_GetByQRToken_NotFound(t *testing.T) {
 	repo, eventID := setupTestParticipantRepo(t)
 	defer cleanupTestEvent(t, repo, eventID)
 
-	participant := &domain.Participant{
-		EventID: eventID,
-		Name:    "John Doe",
-		Email:   "john@example.com",
-		Phone:   "08123456789",
-		QRToken: uuid.New().String(),
-	}
-
-	repo.Create(context.Background(), participant)
-
-	// Update check-in
-	err := repo.UpdateCheckIn(context.Background(), participant.ID)
-	if err != nil {
-		t.Fatal("Failed to update check-in:", err)
-	}
-
-	// Verify check-in
-	found, _ := repo.GetByQRToken(context.Background(), participant.QRToken)
-	if !found.CheckedIn {
-		t.Error("Expected checked_in to be true")
-	}
-	if found.CheckedInAt == nil {
-		t.Error("Expected checked_in_at to be set")
+	_, err := repo.GetByQRToken(context.Background(), "unknown-"+uuid.New().String())
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
 	}
 
-	t.Log("✅ Check-in updated successfully")
+	t.Log("✅ Unknown QR token returns ErrNotFound")
 }
 
 func TestParticipantRepository_CountCheckedInByEventID(t *testing.T) {
@@ -245,14 +568,14 @@ func TestParticipantRepository_CountCheckedInByEventID(t *testing.T) {
 		p := &domain.Participant{
 			EventID: eventID,
 			Name:    "User",
-			Email:   "user@example.com",
+			Email:   fmt.Sprintf("user%d@example.com", i),
 			Phone:   "08123456789",
 			QRToken: uuid.New().String(),
 		}
 		repo.Create(context.Background(), p)
 
 		if i < 2 {
-			repo.UpdateCheckIn(context.Background(), p.ID)
+			checkInTestParticipant(t, repo, p)
 		}
 	}
 
@@ -268,6 +591,221 @@ func TestParticipantRepository_CountCheckedInByEventID(t *testing.T) {
 	t.Log("✅ Count checked-in participants working correctly")
 }
 
+func TestParticipantRepository_CountInsideByEventID(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	// Create 4 participants, check-in 3 of them
+	for i := 0; i < 4; i++ {
+		p := &domain.Participant{
+			EventID: eventID,
+			Name:    "User",
+			Email:   fmt.Sprintf("user%d@example.com", i),
+			Phone:   "08123456789",
+			QRToken: uuid.New().String(),
+		}
+		repo.Create(context.Background(), p)
+
+		if i < 3 {
+			checkInTestParticipant(t, repo, p)
+		}
+	}
+
+	count, err := repo.CountInsideByEventID(context.Background(), eventID)
+	if err != nil {
+		t.Fatal("Failed to count participants inside:", err)
+	}
+
+	if count != 3 {
+		t.Errorf("Expected 3 inside, got %d", count)
+	}
+
+	t.Log("✅ Count participants inside working correctly")
+}
+
+func TestParticipantRepository_Update(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	participant := &domain.Participant{
+		EventID: eventID,
+		Name:    "John Doe",
+		Email:   "john@example.com",
+		Phone:   "08123456789",
+		QRToken: uuid.New().String(),
+	}
+
+	repo.Create(context.Background(), participant)
+	repo.MarkQRSent(context.Background(), participant.ID)
+
+	// Ganti email dan reset status QR
+	participant.Name = "John Smith"
+	participant.Email = "john.smith@example.com"
+	participant.QRSent = false
+	participant.QRSentAt = nil
+
+	if err := repo.Update(context.Background(), participant); err != nil {
+		t.Fatal("Failed to update participant:", err)
+	}
+
+	found, err := repo.GetByID(context.Background(), participant.ID)
+	if err != nil {
+		t.Fatal("Failed to get participant:", err)
+	}
+
+	if found.Name != "John Smith" || found.Email != "john.smith@example.com" {
+		t.Errorf("Expected updated name and email, got %s <%s>", found.Name, found.Email)
+	}
+	if found.QRSent || found.QRSentAt != nil {
+		t.Error("Expected qr_sent to be reset")
+	}
+
+	// Update tanpa perubahan data tetap berhasil
+	if err := repo.Update(context.Background(), found); err != nil {
+		t.Error("Update without changes should succeed, got:", err)
+	}
+
+	t.Log("✅ Participant updated successfully")
+}
+
+func TestParticipantRepository_DuplicateEmail(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	john := &domain.Participant{EventID: eventID, Name: "John Doe", Email: "john@example.com", Phone: "08123456789", QRToken: uuid.New().String()}
+	jane := &domain.Participant{EventID: eventID, Name: "Jane Doe", Email: "jane@example.com", Phone: "08987654321", QRToken: uuid.New().String()}
+	if err := repo.Create(context.Background(), john); err != nil {
+		t.Fatal("Failed to create participant:", err)
+	}
+	if err := repo.Create(context.Background(), jane); err != nil {
+		t.Fatal("Failed to create participant:", err)
+	}
+
+	// Email yang sama di event yang sama ditolak
+	duplicate := &domain.Participant{EventID: eventID, Name: "Johnny", Email: "john@example.com", Phone: "08111", QRToken: uuid.New().String()}
+	if err := repo.Create(context.Background(), duplicate); !errors.Is(err, domain.ErrParticipantAlreadyExists) {
+		t.Errorf("Expected ErrParticipantAlreadyExists on create, got %v", err)
+	}
+
+	jane.Email = "john@example.com"
+	if err := repo.Update(context.Background(), jane); !errors.Is(err, domain.ErrParticipantAlreadyExists) {
+		t.Errorf("Expected ErrParticipantAlreadyExists on update, got %v", err)
+	}
+}
+
+func TestParticipantRepository_ReplacePhones(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	legacy := &domain.Participant{EventID: eventID, Name: "John Doe", Email: "john@example.com", Phone: "0812-3456-789", QRToken: uuid.New().String()}
+	normalized := &domain.Participant{EventID: eventID, Name: "Jane Doe", Email: "jane@example.com", Phone: "+628987654321", QRToken: uuid.New().String()}
+	invalid := &domain.Participant{EventID: eventID, Name: "Bob Doe", Email: "bob@example.com", Phone: "12", QRToken: uuid.New().String()}
+	repo.Create(context.Background(), legacy)
+	repo.Create(context.Background(), normalized)
+	repo.Create(context.Background(), invalid)
+
+	nonE164 := func() map[int64]string {
+		phones := make(map[int64]string)
+		var afterID int64
+		for {
+			participants, err := repo.GetNonE164Phones(context.Background(), afterID, 2)
+			if err != nil {
+				t.Fatal("Failed to get phones:", err)
+			}
+			if len(participants) == 0 {
+				return phones
+			}
+			for _, p := range participants {
+				phones[p.ID] = p.Phone
+			}
+			afterID = participants[len(participants)-1].ID
+		}
+	}
+
+	phones := nonE164()
+	if phones[legacy.ID] != "0812-3456-789" {
+		t.Errorf("Expected legacy phone, got %q", phones[legacy.ID])
+	}
+	if _, ok := phones[normalized.ID]; ok {
+		t.Error("Expected E.164 phone to be excluded")
+	}
+
+	// Phone yang sudah berubah sejak dibaca tidak ditimpa
+	changes := []domain.PhoneChange{
+		{ParticipantID: legacy.ID, OldPhone: "0812-3456-789", NewPhone: "+628123456789"},
+		{ParticipantID: invalid.ID, OldPhone: "0800", NewPhone: "+62800"},
+	}
+	if err := repo.ReplacePhones(context.Background(), changes); err != nil {
+		t.Fatal("Failed to replace phones:", err)
+	}
+	if err := repo.MarkInvalidPhones(context.Background(), []*domain.Participant{invalid}); err != nil {
+		t.Fatal("Failed to mark invalid phones:", err)
+	}
+
+	found, err := repo.GetByID(context.Background(), legacy.ID)
+	if err != nil {
+		t.Fatal("Failed to get participant:", err)
+	}
+	if found.Phone != "+628123456789" {
+		t.Errorf("Expected phone +628123456789, got %s", found.Phone)
+	}
+
+	found, err = repo.GetByID(context.Background(), invalid.ID)
+	if err != nil {
+		t.Fatal("Failed to get participant:", err)
+	}
+	if found.Phone != "12" {
+		t.Errorf("Expected phone 12 to be kept, got %s", found.Phone)
+	}
+
+	// Phone yang sudah dinormalisasi atau ditandai tidak valid tidak diambil lagi
+	if phones := nonE164(); len(phones) != 0 {
+		t.Errorf("Expected no phones left to normalize, got %v", phones)
+	}
+}
+
+func TestParticipantRepository_Update_NotFound(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	err := repo.Update(context.Background(), &domain.Participant{ID: 999999999, Name: "Ghost"})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestParticipantRepository_Delete(t *testing.T) {
+	repo, eventID := setupTestParticipantRepo(t)
+	defer cleanupTestEvent(t, repo, eventID)
+
+	participant := &domain.Participant{
+		EventID: eventID,
+		Name:    "John Doe",
+		Email:   "john@example.com",
+		Phone:   "08123456789",
+		QRToken: uuid.New().String(),
+	}
+
+	repo.Create(context.Background(), participant)
+
+	if err := repo.Delete(context.Background(), participant.ID); err != nil {
+		t.Fatal("Failed to delete participant:", err)
+	}
+
+	_, err := repo.GetByID(context.Background(), participant.ID)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+
+	// Delete kedua kali harus not found
+	err = repo.Delete(context.Background(), participant.ID)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	t.Log("✅ Participant deleted successfully")
+}
+
 func TestParticipantRepository_DeleteByEventID(t *testing.T) {
 	repo, eventID := setupTestParticipantRepo(t)
 	defer cleanupTestEvent(t, repo, eventID)
@@ -277,7 +815,7 @@ func TestParticipantRepository_DeleteByEventID(t *testing.T) {
 		p := &domain.Participant{
 			EventID: eventID,
 			Name:    "User",
-			Email:   "user@example.com",
+			Email:   fmt.Sprintf("user%d@example.com", i),
 			Phone:   "08123456789",
 			QRToken: uuid.New().String(),
 		}
@@ -299,7 +837,7 @@ func TestParticipantRepository_DeleteByEventID(t *testing.T) {
 	t.Log("✅ Participants deleted by event ID successfully")
 }
 
-func BenchmarkBulkCreate(b *testing.B) {
+func BenchmarkImport(b *testing.B) {
 	cfg, _ := config.LoadConfig("../../../.env")
 	db, _ := database.InitDB(cfg)
 	repo := &participantRepository{db: db}
@@ -325,13 +863,13 @@ func BenchmarkBulkCreate(b *testing.B) {
 			participants[j] = &domain.Participant{
 				EventID: eventID,
 				Name:    "User",
-				Email:   "user@example.com",
+				Email:   fmt.Sprintf("user%d@example.com", j),
 				Phone:   "08123456789",
 				QRToken: uuid.New().String(),
 			}
 		}
 
-		repo.BulkCreate(context.Background(), participants)
+		repo.Import(context.Background(), eventID, participants, domain.ImportModeReject, 1000)
 		repo.DeleteByEventID(context.Background(), eventID) // Cleanup for next iteration
 	}
 }
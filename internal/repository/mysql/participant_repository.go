@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/fzndps/eventcheck/internal/domain"
@@ -25,22 +26,32 @@ func NewParticipantRepository(db *sql.DB) repository.ParticipantRepository {
 func (r *participantRepository) Create(ctx context.Context, participant *domain.Participant) error {
 	query := `
 		INSERT INTO participants
-			(event_id, name, email, phone, qr_token,
+			(event_id, name, email, phone, attributes, qr_token,
 			checked_in, checked_in_at, created_at)
-			VALUES (?, ?, ?, ?, ?, ?, ?, NOW())
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())
 	`
 
+	attributes, err := encodeJSON(participant.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed to encode attributes: %v", err)
+	}
+
 	result, err := r.db.ExecContext(ctx, query,
 		participant.EventID,
 		participant.Name,
 		participant.Email,
 		participant.Phone,
+		attributes,
 		participant.QRToken,
 		participant.CheckedIn,
 		participant.CheckedInAt,
 	)
 
 	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.ErrParticipantAlreadyExists
+		}
+
 		return fmt.Errorf("failed to create participant: %v", err)
 	}
 
@@ -53,32 +64,235 @@ func (r *participantRepository) Create(ctx context.Context, participant *domain.
 	return nil
 }
 
-// BulkCreate menyimpan banyak participants sekaligus (untuk CSV upload)
-// Menggunakan transaction untuk atomicity: all or nothing
-func (r *participantRepository) BulkCreate(ctx context.Context, participants []*domain.Participant) error {
-	if len(participants) == 0 {
-		return nil
+// Import menyimpan participant hasil import dalam satu transaction
+// Row event dikunci supaya import bersamaan di event yang sama tidak membuat duplikat
+func (r *participantRepository) Import(
+	ctx context.Context,
+	eventID string,
+	participants []*domain.Participant,
+	mode string,
+	quota int,
+) (*domain.ImportResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	// Mulai transaction menggunakan tx
-	tx, err := r.db.BeginTx(ctx, nil)
+	defer tx.Rollback()
+
+	var lockedID string
+	err = tx.QueryRowContext(ctx, `SELECT id FROM events WHERE id = ? FOR UPDATE`, eventID).Scan(&lockedID)
 	if err != nil {
-		return err
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	plan, err := planImport(ctx, tx, eventID, participants, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hanya participant baru yang memakai kuota
+	if err := plan.Result.CheckQuota(quota); err != nil {
+		return nil, err
+	}
+
+	for _, p := range plan.Updates {
+		if err := updateParticipant(ctx, tx, p); err != nil {
+			return nil, importWriteError(err)
+		}
+	}
+
+	if err := insertParticipants(ctx, tx, plan.Creates); err != nil {
+		return nil, importWriteError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return plan.Result, nil
+}
+
+// importWriteError mengubah pelanggaran unique email menjadi ErrParticipantAlreadyExists
+// Terjadi jika mode update mengganti email participant dengan email participant lain
+func importWriteError(err error) error {
+	if isDuplicateKeyError(err) {
+		return domain.ErrParticipantAlreadyExists
+	}
+
+	return err
+}
+
+// PreviewImport menghitung hasil import tanpa menyimpan perubahan apa pun
+func (r *participantRepository) PreviewImport(
+	ctx context.Context,
+	eventID string,
+	participants []*domain.Participant,
+	mode string,
+) (*domain.ImportResult, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	plan, err := planImport(ctx, tx, eventID, participants, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.Result, nil
+}
+
+// planImport menjalankan domain.PlanImport dengan participant event yang email atau phone-nya sama
+// Registered diisi jumlah seluruh participant event, bukan hanya kandidat duplikat
+func planImport(ctx context.Context, tx *sql.Tx, eventID string, participants []*domain.Participant, mode string) (*domain.ImportPlan, error) {
+	var registered int
+	err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM participants WHERE event_id = ?`, eventID).Scan(&registered)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := getImportCandidates(ctx, tx, eventID, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := domain.PlanImport(existing, participants, mode)
+	plan.Result.Registered = registered
+
+	return plan, nil
+}
+
+// candidateBatchSize jumlah participant import per query kandidat duplikat, 2 placeholder per participant
+const candidateBatchSize = 500
+
+// getImportCandidates mengambil participant event dengan email atau phone yang sama dengan participant import
+// Query memakai index (event_id, email) dan (event_id, phone) sehingga tidak membaca semua participant event
+func getImportCandidates(ctx context.Context, tx *sql.Tx, eventID string, imported []*domain.Participant) ([]*domain.Participant, error) {
+	seen := make(map[int64]bool)
+	var participants []*domain.Participant
+
+	for start := 0; start < len(imported); start += candidateBatchSize {
+		batch := imported[start:min(start+candidateBatchSize, len(imported))]
+
+		candidates, err := getImportCandidateBatch(ctx, tx, eventID, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range candidates {
+			if !seen[p.ID] {
+				seen[p.ID] = true
+				participants = append(participants, p)
+			}
+		}
+	}
+
+	// Participant yang lebih dulu terdaftar dipakai jika email dan phone cocok dengan participant berbeda
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].ID < participants[j].ID
+	})
+
+	return participants, nil
+}
+
+func getImportCandidateBatch(ctx context.Context, tx *sql.Tx, eventID string, batch []*domain.Participant) ([]*domain.Participant, error) {
+	var emails, phones []any
+	for _, p := range batch {
+		if email := strings.TrimSpace(p.Email); email != "" {
+			emails = append(emails, email)
+		}
+		if phone := strings.TrimSpace(p.Phone); phone != "" {
+			phones = append(phones, phone)
+		}
+	}
+
+	var conditions []string
+	args := []any{eventID}
+	if len(emails) > 0 {
+		conditions = append(conditions, "email IN ("+placeholders(len(emails))+")")
+		args = append(args, emails...)
+	}
+	if len(phones) > 0 {
+		conditions = append(conditions, "phone IN ("+placeholders(len(phones))+")")
+		args = append(args, phones...)
+	}
+
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	// Collation kolom email tidak membedakan huruf besar kecil, sama seperti domain.ParticipantMatcher
+	query := `
+		SELECT id, event_id, name, email, phone, attributes, qr_sent, qr_sent_at
+		FROM participants
+		WHERE event_id = ? AND (` + strings.Join(conditions, " OR ") + `)
+	`
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []*domain.Participant
+	for rows.Next() {
+		p := &domain.Participant{}
+		var qrSentAt sql.NullTime
+
+		if err := rows.Scan(&p.ID, &p.EventID, &p.Name, &p.Email, &p.Phone, jsonMap{&p.Attributes}, &p.QRSent, &qrSentAt); err != nil {
+			return nil, err
+		}
+
+		if qrSentAt.Valid {
+			p.QRSentAt = &qrSentAt.Time
+		}
+
+		participants = append(participants, p)
 	}
 
-	defer tx.Rollback() // Rollback semua jika eerror
+	return participants, rows.Err()
+}
+
+// insertBatchSize jumlah row per INSERT, 8 placeholder per row masih jauh di bawah batas 65.535
+const insertBatchSize = 1000
 
+// insertParticipants bulk insert participant di dalam transaction
+func insertParticipants(ctx context.Context, tx *sql.Tx, participants []*domain.Participant) error {
+	// Insert dibagi per batch supaya tidak melebihi batas placeholder dan max_allowed_packet MySQL
+	for start := 0; start < len(participants); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(participants))
+		if err := insertParticipantBatch(ctx, tx, participants[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertParticipantBatch(ctx context.Context, tx *sql.Tx, participants []*domain.Participant) error {
 	// bulk insert query
 	valueStrings := make([]string, 0, len(participants))
-	valueArgs := make([]interface{}, 0, len(participants)*7) // 7 kolom
+	valueArgs := make([]interface{}, 0, len(participants)*8) // 8 kolom
 
 	for _, p := range participants {
-		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, NOW())")
+		attributes, err := encodeJSON(p.Attributes)
+		if err != nil {
+			return fmt.Errorf("failed to encode attributes: %v", err)
+		}
+
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, NOW())")
 		valueArgs = append(valueArgs,
 			p.EventID,
 			p.Name,
 			p.Email,
 			p.Phone,
+			attributes,
 			p.QRToken,
 			false, // checked in default dibuat false
 			nil,   // checked in at default dibuat null
@@ -87,26 +301,38 @@ func (r *participantRepository) BulkCreate(ctx context.Context, participants []*
 
 	query := fmt.Sprintf(`
 		INSERT INTO participants (
-		event_id, name, email, phone, qr_token,
+		event_id, name, email, phone, attributes, qr_token,
 		checked_in, checked_in_at, created_at
 		) VALUES %s
 	`, strings.Join(valueStrings, ","))
 
-	_, err = tx.ExecContext(ctx, query, valueArgs...)
+	_, err := tx.ExecContext(ctx, query, valueArgs...)
+	return err
+}
+
+// updateParticipant mengubah data participant di dalam transaction
+func updateParticipant(ctx context.Context, tx *sql.Tx, p *domain.Participant) error {
+	query := `
+		UPDATE participants
+		SET name = ?, email = ?, phone = ?, attributes = ?, qr_sent = ?, qr_sent_at = ?
+		WHERE id = ?
+	`
+
+	attributes, err := encodeJSON(p.Attributes)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to encode attributes: %v", err)
 	}
 
-	// Commit transaction jika berhasil
-	return tx.Commit()
+	_, err = tx.ExecContext(ctx, query, p.Name, p.Email, p.Phone, attributes, p.QRSent, p.QRSentAt, p.ID)
+	return err
 }
 
 // GetByID mencari participant berdasarkan ID
 func (r *participantRepository) GetByID(ctx context.Context, id int64) (*domain.Participant, error) {
 	query := `
 		SELECT 
-			id, event_id, name, email, phone, qr_token,
-			checked_in, checked_in_at, qr_sent, qr_sent_at, created_at
+			id, event_id, name, email, phone, attributes, qr_token,
+			checked_in, checked_in_at, is_inside, entry_count, qr_sent, qr_sent_at, created_at
 		FROM participants
 		WHERE id = ?
 	`
@@ -120,9 +346,12 @@ func (r *participantRepository) GetByID(ctx context.Context, id int64) (*domain.
 		&p.Name,
 		&p.Email,
 		&p.Phone,
+		jsonMap{&p.Attributes},
 		&p.QRToken,
 		&p.CheckedIn,
 		&checkinAt,
+		&p.IsInside,
+		&p.EntryCount,
 		&p.QRSent,
 		&qrSentAt,
 		&p.CreatedAt,
@@ -151,8 +380,8 @@ func (r *participantRepository) GetByID(ctx context.Context, id int64) (*domain.
 func (r *participantRepository) GetByEventID(ctx context.Context, eventID string) ([]*domain.Participant, error) {
 	query := `
 		SELECT
-			id, event_id, name, email, phone, qr_token,
-			checked_in, checked_in_at, created_at
+			id, event_id, name, email, phone, attributes, qr_token,
+			checked_in, checked_in_at, is_inside, entry_count, created_at
 		FROM participants
 		WHERE event_id = ?
 		ORDER BY created_at DESC
@@ -175,9 +404,12 @@ func (r *participantRepository) GetByEventID(ctx context.Context, eventID string
 			&participant.Name,
 			&participant.Email,
 			&participant.Phone,
+			jsonMap{&participant.Attributes},
 			&participant.QRToken,
 			&participant.CheckedIn,
 			&checkedInAt,
+			&participant.IsInside,
+			&participant.EntryCount,
 			&participant.CreatedAt,
 		)
 
@@ -196,12 +428,212 @@ func (r *participantRepository) GetByEventID(ctx context.Context, eventID string
 
 }
 
+// participantSortColumns kolom yang boleh dipakai untuk sort list participant
+var participantSortColumns = map[string]string{
+	domain.ParticipantSortName:        "name",
+	domain.ParticipantSortCreatedAt:   "created_at",
+	domain.ParticipantSortCheckedInAt: "checked_in_at",
+}
+
+// List mencari participant di event dengan filter, sort dan pagination
+// Kolom sort hanya diambil dari participantSortColumns, default created_at terbaru lebih dulu
+func (r *participantRepository) List(ctx context.Context, eventID string, filter *domain.ParticipantFilter) ([]*domain.Participant, int, error) {
+	where, args := participantFilterWhere(eventID, filter)
+
+	query := fmt.Sprintf(`
+		SELECT
+			id, event_id, name, email, phone, attributes, qr_token,
+			checked_in, checked_in_at, is_inside, entry_count, qr_sent, qr_sent_at, created_at
+		FROM participants
+		WHERE %s
+		ORDER BY %s
+		LIMIT ? OFFSET ?
+	`, where, participantFilterOrder(filter))
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list participant: %v", err)
+	}
+
+	defer rows.Close()
+
+	participants := []*domain.Participant{}
+	for rows.Next() {
+		p, err := scanFilteredParticipant(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		participants = append(participants, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	countQuery := `SELECT COUNT(*) FROM participants WHERE ` + where
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	return participants, total, nil
+}
+
+// Export membaca participant yang cocok dengan filter row per row dari cursor database
+// Limit dan Offset di filter tidak dipakai
+func (r *participantRepository) Export(ctx context.Context, eventID string, filter *domain.ParticipantFilter, fn func(*domain.Participant) error) error {
+	where, args := participantFilterWhere(eventID, filter)
+
+	query := fmt.Sprintf(`
+		SELECT
+			id, event_id, name, email, phone, attributes, qr_token,
+			checked_in, checked_in_at, is_inside, entry_count, qr_sent, qr_sent_at, created_at
+		FROM participants
+		WHERE %s
+		ORDER BY %s
+	`, where, participantFilterOrder(filter))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to export participant: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanFilteredParticipant(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// AttributeKeys mencari semua key attribute participant di event, urut abjad
+func (r *participantRepository) AttributeKeys(ctx context.Context, eventID string) ([]string, error) {
+	query := `
+		SELECT DISTINCT jt.attribute_key
+		FROM participants p,
+			JSON_TABLE(JSON_KEYS(p.attributes), '$[*]' COLUMNS (attribute_key VARCHAR(255) PATH '$')) AS jt
+		WHERE p.event_id = ? AND p.attributes IS NOT NULL
+		ORDER BY jt.attribute_key
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attribute keys: %w", err)
+	}
+
+	defer rows.Close()
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// participantFilterWhere membuat kondisi WHERE dari filter list participant
+func participantFilterWhere(eventID string, filter *domain.ParticipantFilter) (string, []any) {
+	conditions := []string{"event_id = ?"}
+	args := []any{eventID}
+
+	if filter.CheckedIn != nil {
+		conditions = append(conditions, "checked_in = ?")
+		args = append(args, *filter.CheckedIn)
+	}
+
+	if filter.QRSent != nil {
+		conditions = append(conditions, "qr_sent = ?")
+		args = append(args, *filter.QRSent)
+	}
+
+	if filter.Query != "" {
+		pattern := escapeLike(filter.Query) + "%"
+		conditions = append(conditions, "(name LIKE ? OR name LIKE ? OR email LIKE ? OR phone LIKE ?)")
+		args = append(args, pattern, "% "+pattern, pattern, phonePattern(pattern, filter.PhonePrefix))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// phonePattern pattern LIKE untuk kolom phone, awalan E.164 dipakai jika ada
+func phonePattern(pattern, phonePrefix string) string {
+	if phonePrefix == "" {
+		return pattern
+	}
+
+	return escapeLike(phonePrefix) + "%"
+}
+
+// participantFilterOrder membuat ORDER BY dari filter list participant, id sebagai tie breaker
+func participantFilterOrder(filter *domain.ParticipantFilter) string {
+	column, ok := participantSortColumns[filter.SortBy]
+	if !ok {
+		column = "created_at"
+	}
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%s %s, id %s", column, direction, direction)
+}
+
+// scanFilteredParticipant membaca satu row hasil query List dan Export
+func scanFilteredParticipant(rows *sql.Rows) (*domain.Participant, error) {
+	p := &domain.Participant{}
+	var checkedInAt, qrSentAt sql.NullTime
+
+	err := rows.Scan(
+		&p.ID,
+		&p.EventID,
+		&p.Name,
+		&p.Email,
+		&p.Phone,
+		jsonMap{&p.Attributes},
+		&p.QRToken,
+		&p.CheckedIn,
+		&checkedInAt,
+		&p.IsInside,
+		&p.EntryCount,
+		&p.QRSent,
+		&qrSentAt,
+		&p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkedInAt.Valid {
+		p.CheckedInAt = &checkedInAt.Time
+	}
+	if qrSentAt.Valid {
+		p.QRSentAt = &qrSentAt.Time
+	}
+
+	return p, nil
+}
+
 // GetByQRToken mencari participant berdasarkan QR token (untuk check-in)
 func (r *participantRepository) GetByQRToken(ctx context.Context, qrToken string) (*domain.Participant, error) {
 	query := `
 		SELECT
-			id, event_id, name, email, phone, qr_token,
-			checked_in, checked_in_at, created_at
+			id, event_id, name, email, phone, attributes, qr_token,
+			checked_in, checked_in_at, is_inside, entry_count, created_at
 		FROM participants
 		WHERE qr_token = ?
 	`
@@ -215,14 +647,17 @@ func (r *participantRepository) GetByQRToken(ctx context.Context, qrToken string
 		&participant.Name,
 		&participant.Email,
 		&participant.Phone,
+		jsonMap{&participant.Attributes},
 		&participant.QRToken,
 		&participant.CheckedIn,
 		&checkedInAt,
+		&participant.IsInside,
+		&participant.EntryCount,
 		&participant.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("failed to get participant by token %s: %v", qrToken, err)
+			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
@@ -235,6 +670,63 @@ func (r *participantRepository) GetByQRToken(ctx context.Context, qrToken string
 
 }
 
+// Search mencari participant di event berdasarkan awalan nama, email atau phone
+// Nama juga dicocokkan per kata, jadi "budi" menemukan "Ahmad Budi"
+func (r *participantRepository) Search(ctx context.Context, eventID, query, phonePrefix string, limit int) ([]*domain.Participant, error) {
+	pattern := escapeLike(query) + "%"
+	wordPattern := "% " + pattern
+
+	sqlQuery := `
+		SELECT
+			id, event_id, name, email, phone, attributes, qr_token,
+			checked_in, checked_in_at, is_inside, entry_count, created_at
+		FROM participants
+		WHERE event_id = ?
+			AND (name LIKE ? OR name LIKE ? OR email LIKE ? OR phone LIKE ?)
+		ORDER BY name ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, eventID, pattern, wordPattern, pattern, phonePattern(pattern, phonePrefix), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search participant: %v", err)
+	}
+
+	defer rows.Close()
+
+	participants := []*domain.Participant{}
+	for rows.Next() {
+		participant := &domain.Participant{}
+		var checkedInAt sql.NullTime
+		err := rows.Scan(
+			&participant.ID,
+			&participant.EventID,
+			&participant.Name,
+			&participant.Email,
+			&participant.Phone,
+			jsonMap{&participant.Attributes},
+			&participant.QRToken,
+			&participant.CheckedIn,
+			&checkedInAt,
+			&participant.IsInside,
+			&participant.EntryCount,
+			&participant.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if checkedInAt.Valid {
+			participant.CheckedInAt = &checkedInAt.Time
+		}
+
+		participants = append(participants, participant)
+	}
+
+	return participants, rows.Err()
+}
+
 // CountByEventID menghitung jumlah participant di event
 func (r *participantRepository) CountByEventID(ctx context.Context, eventID string) (int, error) {
 	query := `SELECT COUNT(*) FROM participants WHERE event_id = ?`
@@ -261,29 +753,42 @@ func (r *participantRepository) CountCheckedInByEventID(ctx context.Context, eve
 	return count, nil
 }
 
-// UpdateCheckIn mengupdate status check-in participant
-func (r *participantRepository) UpdateCheckIn(ctx context.Context, participantID int64) error {
-	query := `
-		UPDATE participants
-		SET checked_in = TRUE, checked_in_at = NOW()
-		WHERE id = ?
-	`
+// CountInsideByEventID menghitung jumlah participant yang sedang berada di dalam venue
+func (r *participantRepository) CountInsideByEventID(ctx context.Context, eventID string) (int, error) {
+	query := `SELECT COUNT(*) FROM participants WHERE event_id = ? AND is_inside = TRUE`
 
-	result, err := r.db.ExecContext(ctx, query, participantID)
+	var count int
+	err := r.db.QueryRowContext(ctx, query, eventID).Scan(&count)
 	if err != nil {
-		return fmt.Errorf("failed to update checkin: %v", err)
+		return 0, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	return count, nil
+}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("update not found: %v", err)
+// GetAttendanceStats menghitung jumlah participant terdaftar, sudah check-in dan di dalam venue
+// dalam satu query
+func (r *participantRepository) GetAttendanceStats(ctx context.Context, eventID string) (*domain.AttendanceStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(checked_in = TRUE), 0),
+			COALESCE(SUM(is_inside = TRUE), 0)
+		FROM participants
+		WHERE event_id = ?
+	`
+
+	stats := &domain.AttendanceStats{}
+	err := r.db.QueryRowContext(ctx, query, eventID).Scan(
+		&stats.Registered,
+		&stats.CheckedIn,
+		&stats.Inside,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return stats, nil
 }
 
 func (r *participantRepository) MarkQRSent(ctx context.Context, participantID int64) error {
@@ -313,8 +818,8 @@ func (r *participantRepository) MarkQRSent(ctx context.Context, participantID in
 func (r *participantRepository) GetPendingQR(ctx context.Context, eventID string) ([]*domain.Participant, error) {
 	query := `
 		SELECT 
-			id, event_id, name, email, phone, qr_token,
-			checked_in, checked_in_at, qr_sent, qr_sent_at, created_at
+			id, event_id, name, email, phone, attributes, qr_token,
+			checked_in, checked_in_at, is_inside, entry_count, qr_sent, qr_sent_at, created_at
 		FROM participants
 		WHERE event_id = ? AND qr_sent = FALSE
 		ORDER BY created_at ASC
@@ -337,9 +842,12 @@ func (r *participantRepository) GetPendingQR(ctx context.Context, eventID string
 			&p.Name,
 			&p.Email,
 			&p.Phone,
+			jsonMap{&p.Attributes},
 			&p.QRToken,
 			&p.CheckedIn,
 			&checkedInAt,
+			&p.IsInside,
+			&p.EntryCount,
 			&p.QRSent,
 			&qrSentAt,
 			&p.CreatedAt,
@@ -361,6 +869,153 @@ func (r *participantRepository) GetPendingQR(ctx context.Context, eventID string
 	return participants, nil
 }
 
+// Update mengubah name, email, phone, attributes dan status pengiriman QR participant
+func (r *participantRepository) Update(ctx context.Context, participant *domain.Participant) error {
+	query := `
+		UPDATE participants
+		SET name = ?, email = ?, phone = ?, attributes = ?, qr_sent = ?, qr_sent_at = ?
+		WHERE id = ?
+	`
+
+	attributes, err := encodeJSON(participant.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed to encode attributes: %v", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		participant.Name,
+		participant.Email,
+		participant.Phone,
+		attributes,
+		participant.QRSent,
+		participant.QRSentAt,
+		participant.ID,
+	)
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.ErrParticipantAlreadyExists
+		}
+
+		return fmt.Errorf("failed to update participant: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		// MySQL tidak menghitung row yang datanya tidak berubah, pastikan participant memang ada
+		if _, err := r.GetByID(ctx, participant.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetNonE164Phones mengambil participant dengan phone yang belum berformat E.164, urut berdasarkan ID setelah afterID
+// Nomor E.164 selalu diawali "+", phone yang sudah dicatat di invalid_phone dilewati
+// Hanya ID dan Phone yang diisi
+func (r *participantRepository) GetNonE164Phones(ctx context.Context, afterID int64, limit int) ([]*domain.Participant, error) {
+	query := `
+		SELECT id, phone
+		FROM participants
+		WHERE phone <> '' AND phone NOT LIKE '+%'
+			AND (invalid_phone IS NULL OR invalid_phone <> phone)
+			AND id > ?
+		ORDER BY id
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var participants []*domain.Participant
+	for rows.Next() {
+		p := &domain.Participant{}
+		if err := rows.Scan(&p.ID, &p.Phone); err != nil {
+			return nil, err
+		}
+		participants = append(participants, p)
+	}
+
+	return participants, rows.Err()
+}
+
+// ReplacePhones mengganti phone beberapa participant dalam satu query
+// Phone hanya diganti jika masih sama dengan OldPhone
+func (r *participantRepository) ReplacePhones(ctx context.Context, changes []domain.PhoneChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	cases := make([]string, 0, len(changes))
+	caseArgs := make([]any, 0, len(changes)*2)
+	matchArgs := make([]any, 0, len(changes)*2)
+	for _, c := range changes {
+		cases = append(cases, "WHEN ? THEN ?")
+		caseArgs = append(caseArgs, c.ParticipantID, c.NewPhone)
+		matchArgs = append(matchArgs, c.ParticipantID, c.OldPhone)
+	}
+
+	query := `
+		UPDATE participants
+		SET phone = CASE id ` + strings.Join(cases, " ") + ` ELSE phone END
+		WHERE (id, phone) IN (` + rowPlaceholders(len(changes), 2) + `)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, append(caseArgs, matchArgs...)...)
+	return err
+}
+
+// MarkInvalidPhones mencatat phone participant yang tidak bisa dinormalisasi ke invalid_phone
+// Phone yang sudah berubah sejak dibaca tidak dicatat
+func (r *participantRepository) MarkInvalidPhones(ctx context.Context, participants []*domain.Participant) error {
+	if len(participants) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(participants)*2)
+	for _, p := range participants {
+		args = append(args, p.ID, p.Phone)
+	}
+
+	query := `
+		UPDATE participants
+		SET invalid_phone = phone
+		WHERE (id, phone) IN (` + rowPlaceholders(len(participants), 2) + `)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Delete menghapus satu participant
+// participant_id di check_in_logs otomatis menjadi NULL (ON DELETE SET NULL)
+func (r *participantRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM participants WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 // DeleteByEventID menghapus semua participant di event (cascade delete)
 func (r *participantRepository) DeleteByEventID(ctx context.Context, eventID string) error {
 	query := `DELETE FROM participants WHERE event_id = ?`
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/domain/repository"
+)
+
+// authorizeEventAccess memastikan actor boleh mengakses event
+// Organizer harus pemilik event, scanner session hanya berlaku untuk event tempat dia login
+func authorizeEventAccess(
+	ctx context.Context,
+	eventRepo repository.EventRepository,
+	actor *domain.Actor,
+	eventID string,
+) (*domain.Event, error) {
+	event, err := eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	switch actor.Type {
+	case domain.ActorTypeOrganizer:
+		if event.OrganizerID != actor.OrganizerID {
+			return nil, domain.ErrUnauthorizedAccess
+		}
+	case domain.ActorTypeScanner:
+		if actor.EventID != event.ID {
+			return nil, domain.ErrUnauthorizedAccess
+		}
+
+		// Session dari PIN lama tidak berlaku lagi setelah PIN di rotate
+		if actor.PINVersion != event.ScannerPINVersion {
+			return nil, domain.ErrScannerSessionRevoked
+		}
+	default:
+		return nil, domain.ErrUnauthorizedAccess
+	}
+
+	return event, nil
+}
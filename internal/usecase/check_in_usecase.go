@@ -0,0 +1,591 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/domain/repository"
+	"github.com/fzndps/eventcheck/pkg/phone"
+	"github.com/fzndps/eventcheck/pkg/pubsub"
+	"github.com/fzndps/eventcheck/pkg/ticket"
+)
+
+// liveBufferSize jumlah update yang ditampung per dashboard sebelum update dibuang
+const liveBufferSize = 64
+
+type CheckInUsecase struct {
+	eventRepo       repository.EventRepository
+	participantRepo repository.ParticipantRepository
+	checkInLogRepo  repository.CheckInLogRepository
+	hub             *pubsub.Hub
+
+	// Region untuk awalan nomor telepon yang dicari tanpa kode negara
+	phoneRegion string
+}
+
+func NewCheckInUsecase(
+	eventRepo repository.EventRepository,
+	participantRepo repository.ParticipantRepository,
+	checkInLogRepo repository.CheckInLogRepository,
+	hub *pubsub.Hub,
+	phoneRegion string,
+) *CheckInUsecase {
+	return &CheckInUsecase{
+		eventRepo:       eventRepo,
+		participantRepo: participantRepo,
+		checkInLogRepo:  checkInLogRepo,
+		hub:             hub,
+		phoneRegion:     phoneRegion,
+	}
+}
+
+// CheckIn mencatat kehadiran participant berdasarkan token hasil scan QR
+// Setiap scan disimpan di check-in log, termasuk token yang tidak dikenal
+// Jika scan ditolak karena participant masih di dalam, batas entry sudah habis atau
+// di luar jam check-in, response tetap dikembalikan bersama error nya supaya
+// scanner bisa menampilkan status participant
+func (u *CheckInUsecase) CheckIn(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	req *domain.CheckInRequest,
+) (*domain.CheckInResponse, error) {
+	return u.scan(ctx, actor, eventID, domain.CheckInActionCheckIn, &req.ScanInfo, req.OverrideWindow)
+}
+
+// CheckOut mencatat participant keluar dari venue
+// Participant yang sudah check-out bisa masuk lagi sesuai re-entry policy event
+func (u *CheckInUsecase) CheckOut(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	scan *domain.ScanInfo,
+) (*domain.CheckInResponse, error) {
+	return u.scan(ctx, actor, eventID, domain.CheckInActionCheckOut, scan, false)
+}
+
+func (u *CheckInUsecase) scan(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	action string,
+	scan *domain.ScanInfo,
+	overrideWindow bool,
+) (*domain.CheckInResponse, error) {
+	// Cek authorization untuk memastikan actor boleh scan di event ini
+	event, err := authorizeEventAccess(ctx, u.eventRepo, actor, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if overrideWindow && actor.Type != domain.ActorTypeOrganizer {
+		return nil, domain.ErrWindowOverrideForbidden
+	}
+
+	entry := domain.NewCheckInLog(actor, eventID, action, scan, time.Now())
+
+	participant, err := u.recordScan(ctx, event, entry, overrideWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewCheckInResponse(participant), scanResultError(entry.Result)
+}
+
+// ManualCheckIn mencatat check-in participant yang dipilih petugas dari hasil pencarian
+// Dipakai saat participant kehilangan QR, log nya ditandai dengan method manual
+func (u *CheckInUsecase) ManualCheckIn(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	req *domain.ManualCheckInRequest,
+) (*domain.CheckInResponse, error) {
+	// Cek authorization untuk memastikan actor boleh check-in di event ini
+	event, err := authorizeEventAccess(ctx, u.eventRepo, actor, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.OverrideWindow && actor.Type != domain.ActorTypeOrganizer {
+		return nil, domain.ErrWindowOverrideForbidden
+	}
+
+	participant, err := u.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	// Participant event lain dianggap tidak ada
+	if participant.EventID != event.ID {
+		return nil, domain.ErrNotFound
+	}
+
+	scan := &domain.ScanInfo{
+		DeviceID: req.DeviceID,
+		Gate:     req.Gate,
+	}
+	entry := domain.NewCheckInLog(actor, eventID, domain.CheckInActionCheckIn, scan, time.Now())
+	entry.Method = domain.CheckInMethodManual
+	entry.ParticipantID = &participant.ID
+	entry.Result = domain.CheckInResultAccepted
+	applyCheckInWindow(event, entry, req.OverrideWindow)
+
+	participant, err = u.record(ctx, event, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewCheckInResponse(participant), scanResultError(entry.Result)
+}
+
+// Undo membatalkan check-in participant, misalnya salah scan atau scan percobaan
+// Log sebelumnya tetap disimpan sebagai history dan ditandai reverted,
+// sedangkan alasan dan actor yang membatalkan dicatat di log undo
+func (u *CheckInUsecase) Undo(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	req *domain.UndoCheckInRequest,
+) (*domain.CheckInResponse, error) {
+	// Cek authorization untuk memastikan actor boleh mengubah check-in di event ini
+	event, err := authorizeEventAccess(ctx, u.eventRepo, actor, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := u.participantRepo.GetByID(ctx, req.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	// Participant event lain dianggap tidak ada
+	if participant.EventID != event.ID {
+		return nil, domain.ErrNotFound
+	}
+
+	scan := &domain.ScanInfo{
+		DeviceID: req.DeviceID,
+		Gate:     req.Gate,
+	}
+	entry := domain.NewCheckInLog(actor, eventID, domain.CheckInActionUndo, scan, time.Now())
+	entry.Method = domain.CheckInMethodManual
+	entry.ParticipantID = &participant.ID
+	entry.Reason = strings.TrimSpace(req.Reason)
+	entry.Result = domain.CheckInResultAccepted
+
+	participant, err = u.record(ctx, event, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewCheckInResponse(participant), scanResultError(entry.Result)
+}
+
+// Search mencari participant di event berdasarkan nama, email atau phone
+// untuk check-in manual
+func (u *CheckInUsecase) Search(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	req *domain.ParticipantSearchRequest,
+) (*domain.ParticipantSearchResponse, error) {
+	if _, err := authorizeEventAccess(ctx, u.eventRepo, actor, eventID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	query := strings.TrimSpace(req.Query)
+	participants, err := u.participantRepo.Search(ctx, eventID, query, phone.SearchPrefix(query, u.phoneRegion), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search participants: %w", err)
+	}
+
+	res := &domain.ParticipantSearchResponse{
+		Participants: make([]*domain.ParticipantSearchResult, 0, len(participants)),
+	}
+
+	for _, p := range participants {
+		res.Participants = append(res.Participants, domain.NewParticipantSearchResult(p))
+	}
+
+	return res, nil
+}
+
+// Lookup mengecek status participant dari token QR tanpa melakukan check-in
+func (u *CheckInUsecase) Lookup(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	qrToken string,
+) (*domain.CheckInResponse, error) {
+	// Cek authorization untuk memastikan actor boleh scan di event ini
+	event, err := authorizeEventAccess(ctx, u.eventRepo, actor, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := u.resolveParticipant(ctx, event, qrToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewCheckInResponse(participant), nil
+}
+
+// SyncOffline menerapkan check-in yang dicatat scanner saat offline
+// Setiap record diproses idempotent: upload ulang record yang sama tidak mengubah status,
+// dan jika beberapa device men-scan participant yang sama, waktu scan paling awal yang disimpan
+func (u *CheckInUsecase) SyncOffline(
+	ctx context.Context,
+	actor *domain.Actor,
+	eventID string,
+	records []*domain.OfflineCheckInRecord,
+) (*domain.OfflineCheckInBatchResponse, error) {
+	// Cek authorization sekali untuk seluruh batch
+	event, err := authorizeEventAccess(ctx, u.eventRepo, actor, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domain.OfflineCheckInBatchResponse{
+		Results: make([]*domain.OfflineCheckInResult, 0, len(records)),
+	}
+
+	now := time.Now()
+
+	for i, record := range records {
+		result := &domain.OfflineCheckInResult{
+			Index:    i,
+			QRToken:  record.QRToken,
+			DeviceID: record.DeviceID,
+		}
+		res.Results = append(res.Results, result)
+
+		// Jam device bisa maju, waktu scan tidak boleh melewati waktu server
+		scannedAt := record.ScannedAt
+		if scannedAt.After(now) {
+			scannedAt = now
+		}
+
+		scan := &domain.ScanInfo{
+			QRToken:  record.QRToken,
+			DeviceID: record.DeviceID,
+			Gate:     record.Gate,
+		}
+		entry := domain.NewCheckInLog(actor, eventID, domain.CheckInActionCheckIn, scan, scannedAt)
+
+		participant, err := u.recordScan(ctx, event, entry, false)
+		switch {
+		case errors.Is(err, domain.ErrInvalidQRToken):
+			result.Result = domain.SyncResultUnknownToken
+			res.UnknownToken++
+			continue
+		case errors.Is(err, domain.ErrParticipantWrongEvent):
+			result.Result = domain.SyncResultWrongEvent
+			res.WrongEvent++
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		result.ParticipantID = participant.ID
+		result.Name = participant.Name
+		result.CheckedInAt = participant.CheckedInAt
+
+		switch entry.Result {
+		case domain.CheckInResultAccepted:
+			result.Result = domain.SyncResultApplied
+			res.Applied++
+		case domain.CheckInResultEntryLimitReached:
+			result.Result = domain.SyncResultEntryLimitReached
+			res.EntryLimitReached++
+		case domain.CheckInResultNotOpen, domain.CheckInResultClosed:
+			result.Result = domain.SyncResultOutsideWindow
+			res.OutsideWindow++
+		default:
+			result.Result = domain.SyncResultDuplicate
+			res.Duplicate++
+		}
+	}
+
+	return res, nil
+}
+
+// ListLogs menampilkan check-in log di event dengan pagination
+func (u *CheckInUsecase) ListLogs(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	page, limit int,
+) (*domain.CheckInLogListResponse, error) {
+	if _, err := authorizeEventAccess(ctx, u.eventRepo, domain.NewOrganizerActor(organizerID), eventID); err != nil {
+		return nil, err
+	}
+
+	page, limit, offset := normalizePagination(page, limit)
+
+	logs, total, err := u.checkInLogRepo.GetByEventID(ctx, eventID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in logs: %w", err)
+	}
+
+	return newCheckInLogListResponse(logs, total, page, limit), nil
+}
+
+// ListParticipantLogs menampilkan check-in log milik satu participant
+func (u *CheckInUsecase) ListParticipantLogs(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	participantID int64,
+	page, limit int,
+) (*domain.CheckInLogListResponse, error) {
+	if _, err := authorizeEventAccess(ctx, u.eventRepo, domain.NewOrganizerActor(organizerID), eventID); err != nil {
+		return nil, err
+	}
+
+	participant, err := u.participantRepo.GetByID(ctx, participantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	if participant.EventID != eventID {
+		return nil, domain.ErrUnauthorizedAccess
+	}
+
+	page, limit, offset := normalizePagination(page, limit)
+
+	logs, total, err := u.checkInLogRepo.GetByParticipantID(ctx, participantID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in logs: %w", err)
+	}
+
+	return newCheckInLogListResponse(logs, total, page, limit), nil
+}
+
+// recordScan mencari participant dari token di log lalu menyimpan log nya
+// Scan dengan token tidak dikenal atau dari event lain tetap disimpan sebelum error dikembalikan
+func (u *CheckInUsecase) recordScan(
+	ctx context.Context,
+	event *domain.Event,
+	entry *domain.CheckInLog,
+	overrideWindow bool,
+) (*domain.Participant, error) {
+	participant, resolveErr := u.resolveParticipant(ctx, event, entry.QRToken)
+	switch {
+	case errors.Is(resolveErr, domain.ErrInvalidQRToken):
+		entry.Result = domain.CheckInResultUnknownToken
+	case errors.Is(resolveErr, domain.ErrParticipantWrongEvent):
+		entry.Result = domain.CheckInResultWrongEvent
+	case resolveErr != nil:
+		return nil, resolveErr
+	default:
+		entry.ParticipantID = &participant.ID
+		// Result final ditentukan repository saat row participant sudah dikunci
+		entry.Result = domain.CheckInResultAccepted
+		applyCheckInWindow(event, entry, overrideWindow)
+	}
+
+	if resolveErr != nil {
+		if err := u.checkInLogRepo.Record(ctx, entry, event.EntryLimit()); err != nil {
+			return nil, fmt.Errorf("failed to record check-in: %w", err)
+		}
+
+		u.publish(ctx, entry, nil)
+		return nil, resolveErr
+	}
+
+	return u.record(ctx, event, entry)
+}
+
+// record menyimpan log untuk participant yang sudah diketahui
+// lalu mengambil ulang participant untuk mendapatkan status check-in terbaru
+func (u *CheckInUsecase) record(ctx context.Context, event *domain.Event, entry *domain.CheckInLog) (*domain.Participant, error) {
+	if err := u.checkInLogRepo.Record(ctx, entry, event.EntryLimit()); err != nil {
+		return nil, fmt.Errorf("failed to record check-in: %w", err)
+	}
+
+	participant, err := u.participantRepo.GetByID(ctx, *entry.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	u.publish(ctx, entry, participant)
+
+	return participant, nil
+}
+
+// WatchAttendance mendaftarkan dashboard organizer ke live attendance stream event
+// Return statistik saat ini dan subscription yang menerima *domain.AttendanceUpdate
+// Subscription wajib di Close setelah dashboard terputus
+func (u *CheckInUsecase) WatchAttendance(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+) (*domain.AttendanceStats, *pubsub.Subscription, error) {
+	if _, err := authorizeEventAccess(ctx, u.eventRepo, domain.NewOrganizerActor(organizerID), eventID); err != nil {
+		return nil, nil, err
+	}
+
+	// Subscribe sebelum menghitung statistik supaya tidak ada scan yang terlewat
+	sub := u.hub.Subscribe(liveTopic(eventID), liveBufferSize)
+
+	stats, err := u.participantRepo.GetAttendanceStats(ctx, eventID)
+	if err != nil {
+		sub.Close()
+		return nil, nil, fmt.Errorf("failed to get attendance stats: %w", err)
+	}
+
+	return stats, sub, nil
+}
+
+// publish mengirim hasil scan ke dashboard yang sedang mendengarkan event
+// Statistik hanya dihitung jika ada dashboard yang terhubung
+func (u *CheckInUsecase) publish(ctx context.Context, entry *domain.CheckInLog, participant *domain.Participant) {
+	topic := liveTopic(entry.EventID)
+	if !u.hub.HasSubscribers(topic) {
+		return
+	}
+
+	stats, err := u.participantRepo.GetAttendanceStats(ctx, entry.EventID)
+	if err != nil {
+		// Scan sudah tersimpan, kegagalan live update tidak boleh menggagalkan check-in
+		log.Printf("Failed to get attendance stats for event %s: %v", entry.EventID, err)
+		return
+	}
+
+	update := &domain.AttendanceUpdate{
+		Log:   entry,
+		Stats: stats,
+	}
+
+	if participant != nil {
+		update.Participant = domain.NewCheckInResponse(participant)
+	}
+
+	u.hub.Publish(topic, update)
+}
+
+func liveTopic(eventID string) string {
+	return "attendance:" + eventID
+}
+
+// resolveParticipant mencari participant dari isi QR dan memastikan QR milik event
+// QR signed diverifikasi dengan public key event, QR token hex lama dicari langsung di database
+func (u *CheckInUsecase) resolveParticipant(ctx context.Context, event *domain.Event, qrToken string) (*domain.Participant, error) {
+	qrToken = strings.TrimSpace(qrToken)
+
+	if ticket.IsSigned(qrToken) {
+		return u.resolveSignedTicket(ctx, event, qrToken)
+	}
+
+	participant, err := u.participantRepo.GetByQRToken(ctx, qrToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidQRToken
+		}
+		return nil, fmt.Errorf("failed to get participant by QR token: %w", err)
+	}
+
+	// Token harus milik event yang sedang di scan
+	if participant.EventID != event.ID {
+		return nil, domain.ErrParticipantWrongEvent
+	}
+
+	return participant, nil
+}
+
+func (u *CheckInUsecase) resolveSignedTicket(ctx context.Context, event *domain.Event, qrToken string) (*domain.Participant, error) {
+	claims, err := ticket.Parse(qrToken)
+	if err != nil {
+		return nil, domain.ErrInvalidQRToken
+	}
+
+	// Tiket event lain tidak perlu diverifikasi dengan key event ini
+	if claims.EventID != event.ID {
+		return nil, domain.ErrParticipantWrongEvent
+	}
+
+	// Event tanpa ticket key tidak pernah menerbitkan QR signed
+	if !event.HasTicketKey() {
+		return nil, domain.ErrInvalidQRToken
+	}
+
+	// Signature palsu diperlakukan sama dengan token yang tidak dikenal
+	if _, err := ticket.Verify(event.TicketPublicKey, qrToken); err != nil {
+		return nil, domain.ErrInvalidQRToken
+	}
+
+	participant, err := u.participantRepo.GetByID(ctx, claims.ParticipantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidQRToken
+		}
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	if participant.EventID != event.ID {
+		return nil, domain.ErrInvalidQRToken
+	}
+
+	return participant, nil
+}
+
+// applyCheckInWindow menolak check-in di luar jam check-in event
+// Jika organizer melakukan override, scan diterima dan log ditandai window_override
+func applyCheckInWindow(event *domain.Event, entry *domain.CheckInLog, override bool) {
+	if entry.Action != domain.CheckInActionCheckIn {
+		return
+	}
+
+	result := event.CheckInWindowResult(entry.ScannedAt)
+	if result == domain.CheckInResultAccepted {
+		return
+	}
+
+	if override {
+		entry.WindowOverride = true
+		return
+	}
+
+	entry.Result = result
+}
+
+// scanResultError mengubah result scan yang ditolak menjadi error
+func scanResultError(result string) error {
+	switch result {
+	case domain.CheckInResultDuplicate:
+		return domain.ErrParticipantCheckedIn
+	case domain.CheckInResultEntryLimitReached:
+		return domain.ErrEntryLimitReached
+	case domain.CheckInResultNotInside:
+		return domain.ErrParticipantNotInside
+	case domain.CheckInResultNotCheckedIn:
+		return domain.ErrParticipantNotCheckedIn
+	case domain.CheckInResultNotOpen:
+		return domain.ErrCheckInNotOpen
+	case domain.CheckInResultClosed:
+		return domain.ErrCheckInClosed
+	default:
+		return nil
+	}
+}
+
+func newCheckInLogListResponse(logs []*domain.CheckInLog, total, page, limit int) *domain.CheckInLogListResponse {
+	return &domain.CheckInLogListResponse{
+		Logs:      logs,
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+		TotalPage: totalPages(total, limit),
+	}
+}
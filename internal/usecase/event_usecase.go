@@ -5,22 +5,28 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/fzndps/eventcheck/config"
 	"github.com/fzndps/eventcheck/internal/domain"
 	"github.com/fzndps/eventcheck/internal/domain/repository"
-	"github.com/fzndps/eventcheck/pkg/random"
 	"github.com/fzndps/eventcheck/pkg/slug"
+	"github.com/fzndps/eventcheck/pkg/ticket"
 	"github.com/google/uuid"
 )
 
+// eventDetailParticipantLimit jumlah participant yang ditampilkan di detail event
+const eventDetailParticipantLimit = 20
+
 type EventUsecase struct {
 	eventRepo      repository.EventRepository
 	participanRepo repository.ParticipantRepository
+	cfg            *config.Config
 }
 
-func NewEventUsecase(eventRepo repository.EventRepository, participantRepo repository.ParticipantRepository) *EventUsecase {
+func NewEventUsecase(eventRepo repository.EventRepository, participantRepo repository.ParticipantRepository, cfg *config.Config) *EventUsecase {
 	return &EventUsecase{
 		eventRepo:      eventRepo,
 		participanRepo: participantRepo,
+		cfg:            cfg,
 	}
 }
 
@@ -52,10 +58,17 @@ func (u *EventUsecase) CreateEvent(
 		eventSlug = slug.GenerateUnique(req.Name)
 	}
 
-	// Gnenerate random 4 digit scanner PIN dengan crypto/rand karena lebih secure
-	scannerPIN, err := random.GeneratePIN()
+	// Generate random scanner PIN dengan crypto/rand karena lebih secure
+	// yang disimpan ke database hanya hash nya
+	scannerPIN, scannerPINHash, err := generateScannerPIN(u.cfg.Scanner.PINLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate key pair untuk tanda tangan QR tiket participant
+	ticketPublicKey, ticketPrivateKey, err := ticket.GenerateKeyPair()
 	if err != nil {
-		return nil, fmt.Errorf("failed generate random PIN: %w", err)
+		return nil, err
 	}
 
 	// Kalkulasi total price berdasarkan partisipan
@@ -63,16 +76,29 @@ func (u *EventUsecase) CreateEvent(
 
 	// buat object event
 	event := &domain.Event{
-		ID:               eventID,
-		OrganizerID:      int64(organizerID),
-		Name:             req.Name,
-		Slug:             eventSlug,
-		Date:             req.Date.Time,
-		Venue:            req.Venue,
-		ParticipantCount: req.ParticipantCount,
-		TotalPrice:       totalPrice,
-		PaymentStatus:    domain.PaymentStatusPending,
-		ScannerPIN:       scannerPIN,
+		ID:                eventID,
+		OrganizerID:       int64(organizerID),
+		Name:              req.Name,
+		Slug:              eventSlug,
+		Date:              req.Date.Time,
+		Venue:             req.Venue,
+		ParticipantCount:  req.ParticipantCount,
+		TotalPrice:        totalPrice,
+		PaymentStatus:     domain.PaymentStatusPending,
+		ScannerPINHash:    scannerPINHash,
+		ScannerPINVersion: 1,
+		ScannerPIN:        scannerPIN, // plain text hanya dikirim sekali di response create
+		ReentryPolicy:     req.ReentryPolicy,
+		TicketPublicKey:   ticketPublicKey,
+		TicketPrivateKey:  ticketPrivateKey,
+
+		CheckInOpensBefore: req.CheckInOpensBefore,
+		CheckInClosesAfter: req.CheckInClosesAfter,
+	}
+
+	// max_entries hanya disimpan untuk policy limited
+	if req.ReentryPolicy == domain.ReentryPolicyLimited {
+		event.MaxEntries = req.MaxEntries
 	}
 
 	if err := u.eventRepo.Create(ctx, event); err != nil {
@@ -143,8 +169,13 @@ func (u *EventUsecase) GetEventDetail(
 		return nil, domain.ErrUnauthorizedAccess
 	}
 
-	// dapatkan partisipan dari repo
-	participant, err := u.participanRepo.GetByEventID(ctx, eventID)
+	// Detail event hanya berisi participant terbaru,
+	// list lengkap diambil dari endpoint list participant
+	participant, total, err := u.participanRepo.List(ctx, eventID, &domain.ParticipantFilter{
+		SortBy:   domain.ParticipantSortCreatedAt,
+		SortDesc: true,
+		Limit:    eventDetailParticipantLimit,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all participant: %w", err)
 	}
@@ -160,12 +191,21 @@ func (u *EventUsecase) GetEventDetail(
 		return nil, fmt.Errorf("failed to count all participant: %w", err)
 	}
 
+	participantInside, err := u.participanRepo.CountInsideByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count participant inside: %w", err)
+	}
+
 	// return response
 	res := &domain.EventDetailResponse{
 		Event:                 event,
 		Participants:          participant,
+		ParticipantsLimit:     eventDetailParticipantLimit,
+		HasMoreParticipants:   total > len(participant),
+		ParticipantsURL:       fmt.Sprintf("/api/v1/events/%s/participants", eventID),
 		ParticipantRegistered: participantRegistered,
 		ParticipantCheckedIn:  participantCheckedIn,
+		ParticipantInside:     participantInside,
 	}
 
 	return res, nil
@@ -216,6 +256,37 @@ func (u *EventUsecase) UpdateEvent(
 		event.Venue = req.Venue
 	}
 
+	// Update re-entry policy, max_entries hanya berlaku untuk policy limited
+	if req.ReentryPolicy != "" {
+		event.ReentryPolicy = req.ReentryPolicy
+	}
+
+	if req.MaxEntries != nil {
+		event.MaxEntries = *req.MaxEntries
+	}
+
+	if event.ReentryPolicy != domain.ReentryPolicyLimited {
+		event.MaxEntries = 0
+	}
+
+	if err := domain.ValidateReentryPolicy(event.ReentryPolicy, event.MaxEntries); err != nil {
+		return nil, err
+	}
+
+	// Update jam check-in jika dikirim, clear menghapus batas yang lama lebih dulu
+	if req.ClearCheckInWindow {
+		event.CheckInOpensBefore = nil
+		event.CheckInClosesAfter = nil
+	}
+
+	if req.CheckInOpensBefore != nil {
+		event.CheckInOpensBefore = req.CheckInOpensBefore
+	}
+
+	if req.CheckInClosesAfter != nil {
+		event.CheckInClosesAfter = req.CheckInClosesAfter
+	}
+
 	// save update ke database
 	if err := u.eventRepo.Update(ctx, event); err != nil {
 		return nil, fmt.Errorf("failed to update event: %w", err)
@@ -224,6 +295,88 @@ func (u *EventUsecase) UpdateEvent(
 	return event, nil
 }
 
+// BuyCapacity menambah kuota participant event dan menghitung selisih harga yang harus dibayar
+// Kuota tambahan aktif setelah pembayaran diverifikasi lewat VerifyCapacityPayment
+func (u *EventUsecase) BuyCapacity(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	req *domain.BuyCapacityRequest,
+) (*domain.BuyCapacityResponse, error) {
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.OrganizerID != organizerID {
+		return nil, domain.ErrUnauthorizedAccess
+	}
+
+	res, err := u.eventRepo.AddCapacity(ctx, eventID, req.AdditionalParticipants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add capacity: %w", err)
+	}
+
+	return res, nil
+}
+
+// VerifyCapacityPayment mengaktifkan kuota tambahan event setelah pembayaran selisih harga diverifikasi
+func (u *EventUsecase) VerifyCapacityPayment(ctx context.Context, eventID string, paidPrice int) (*domain.BuyCapacityResponse, error) {
+	res, err := u.eventRepo.ApplyPendingCapacity(ctx, eventID, paidPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply pending capacity: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetAttributeSchema mengambil schema attribute participant event
+func (u *EventUsecase) GetAttributeSchema(ctx context.Context, organizerID int64, eventID string) (domain.AttributeSchema, error) {
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.OrganizerID != organizerID {
+		return nil, domain.ErrUnauthorizedAccess
+	}
+
+	if event.AttributeSchema == nil {
+		return domain.AttributeSchema{}, nil
+	}
+
+	return event.AttributeSchema, nil
+}
+
+// UpdateAttributeSchema mengganti schema attribute participant event
+// Attribute participant yang sudah tersimpan tidak divalidasi ulang
+func (u *EventUsecase) UpdateAttributeSchema(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	req *domain.AttributeSchemaRequest,
+) (domain.AttributeSchema, error) {
+	isOwned, err := u.eventRepo.IsOwnedBy(ctx, eventID, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner event: %w", err)
+	}
+
+	if !isOwned {
+		return nil, domain.ErrUnauthorizedAccess
+	}
+
+	schema := req.Attributes.Normalize()
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.eventRepo.UpdateAttributeSchema(ctx, eventID, schema); err != nil {
+		return nil, fmt.Errorf("failed to update attribute schema: %w", err)
+	}
+
+	return schema, nil
+}
+
 func (u *EventUsecase) DeleteEvent(
 	ctx context.Context,
 	organizerID int64,
@@ -0,0 +1,327 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/pkg/csv"
+	"github.com/fzndps/eventcheck/pkg/random"
+	"github.com/google/uuid"
+)
+
+// importWorkerPollInterval jeda worker mengecek job queued yang belum diambil
+const importWorkerPollInterval = 5 * time.Second
+
+// SubmitImportJob menyimpan file import sebagai job yang diproses di background
+// Header file dicek lebih dulu supaya kesalahan kolom langsung dilaporkan ke organizer
+func (u *ParticipantUsecase) SubmitImportJob(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	file io.Reader,
+	opts *domain.ImportOptions,
+) (*domain.ImportJobResponse, error) {
+	if err := normalizeImportOptions(opts); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.getOwnedEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+
+	if err := u.resolveImportMapping(ctx, organizerID, opts); err != nil {
+		return nil, err
+	}
+
+	content, err := readImportFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := openImportFile(bytes.NewReader(content), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", opts.Format, err)
+	}
+
+	total, err := stream.CountRows()
+	stream.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rows: %w", err)
+	}
+
+	if total == 0 {
+		return nil, emptyImportError(opts.Format)
+	}
+
+	job := &domain.ImportJob{
+		ID:          uuid.New().String(),
+		EventID:     eventID,
+		OrganizerID: organizerID,
+		Status:      domain.ImportJobQueued,
+		Options:     opts,
+		TotalRows:   total,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := u.importJobRepo.Create(ctx, job, content); err != nil {
+		return nil, err
+	}
+
+	// Worker yang sedang menunggu langsung mengambil job baru
+	select {
+	case u.importWake <- struct{}{}:
+	default:
+	}
+
+	return domain.NewImportJobResponse(job), nil
+}
+
+// GetImportJob mengambil status import job milik organizer
+func (u *ParticipantUsecase) GetImportJob(ctx context.Context, organizerID int64, jobID string) (*domain.ImportJobResponse, error) {
+	job, err := u.importJobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Job milik organizer lain diperlakukan sebagai tidak ditemukan
+	if job.OrganizerID != organizerID {
+		return nil, domain.ErrNotFound
+	}
+
+	return domain.NewImportJobResponse(job), nil
+}
+
+// StartImportWorker menjalankan worker import job di background sampai ctx selesai
+func (u *ParticipantUsecase) StartImportWorker(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(importWorkerPollInterval)
+		defer ticker.Stop()
+
+		for {
+			u.runQueuedImportJobs(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-u.importWake:
+			}
+		}
+	}()
+}
+
+// runQueuedImportJobs memproses semua job queued satu per satu
+func (u *ParticipantUsecase) runQueuedImportJobs(ctx context.Context) {
+	// Job yang terhenti di tengah jalan tidak dilanjutkan karena sebagian chunk sudah tersimpan
+	stale := time.Now().Add(-domain.ImportJobStaleAfter)
+	if err := u.importJobRepo.FailStale(ctx, stale, "import was interrupted, please upload the file again"); err != nil {
+		log.Printf("Failed to fail stale import jobs: %v", err)
+	}
+
+	for ctx.Err() == nil {
+		job, err := u.importJobRepo.ClaimNext(ctx)
+		if err != nil {
+			if !errors.Is(err, domain.ErrNotFound) {
+				log.Printf("Failed to claim import job: %v", err)
+			}
+			return
+		}
+
+		err = u.processImportJob(ctx, job)
+		job.Finish(err, time.Now())
+
+		if err := u.importJobRepo.Finish(ctx, job); err != nil {
+			log.Printf("Failed to finish import job %s: %v", job.ID, err)
+		}
+	}
+}
+
+// processImportJob membaca file row per row dan menyimpan participant per chunk
+// Progress disimpan setiap domain.ImportChunkSize row
+// Kuota dicek untuk seluruh file lebih dulu supaya file yang melebihi kuota tidak tersimpan sebagian
+func (u *ParticipantUsecase) processImportJob(ctx context.Context, job *domain.ImportJob) error {
+	event, err := u.getOwnedEvent(ctx, job.OrganizerID, job.EventID)
+	if err != nil {
+		return err
+	}
+
+	content, err := u.importJobRepo.GetContent(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.checkImportJobQuota(ctx, event, job, content); err != nil {
+		return err
+	}
+
+	return u.readImportChunks(event, job.Options, content, func(chunk *importJobChunk) error {
+		job.Headers = chunk.headers
+		job.ProcessedRows += chunk.read
+		job.AddErrors(chunk.rowErrors...)
+
+		if err := u.importChunk(ctx, event, job, chunk.rows); err != nil {
+			return err
+		}
+
+		return u.importJobRepo.UpdateProgress(ctx, job)
+	})
+}
+
+// checkImportJobQuota menghitung participant baru dari seluruh file tanpa menyimpan data
+// Duplikat di dalam file sudah ditolak, jadi participant baru dari setiap chunk bisa dijumlahkan
+// Kuota tetap dicek lagi per chunk di bawah lock event untuk participant yang ditambah di tengah import
+// Progress disimpan setiap chunk supaya job dengan file besar tidak dianggap terhenti oleh FailStale
+func (u *ParticipantUsecase) checkImportJobQuota(ctx context.Context, event *domain.Event, job *domain.ImportJob, content []byte) error {
+	registered, created := 0, 0
+
+	err := u.readImportChunks(event, job.Options, content, func(chunk *importJobChunk) error {
+		if err := u.importJobRepo.UpdateProgress(ctx, job); err != nil {
+			return err
+		}
+
+		if len(chunk.rows) == 0 {
+			return nil
+		}
+
+		participants := make([]*domain.Participant, 0, len(chunk.rows))
+		for _, row := range chunk.rows {
+			participants = append(participants, row.Participant)
+		}
+
+		result, err := u.participanRepo.PreviewImport(ctx, event.ID, participants, job.Options.Mode)
+		if err != nil {
+			return fmt.Errorf("failed to preview import: %w", err)
+		}
+
+		registered = result.Registered
+		created += result.Created
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if created == 0 {
+		return nil
+	}
+
+	return quotaError(event, domain.CheckParticipantQuota(event.ParticipantCount, registered, created))
+}
+
+// importJobChunk row file import job yang dibaca dalam satu chunk
+type importJobChunk struct {
+	headers   []string                 // header file, sama untuk semua chunk
+	rows      []*csv.ParsedRow         // row valid yang siap disimpan
+	rowErrors []*domain.ImportRowError // row yang ditolak
+	read      int                      // jumlah row yang dibaca
+}
+
+// readImportChunks membaca file import job row per row dan memanggil fn setiap domain.ImportChunkSize row
+// fn dipanggil sekali lagi untuk sisa row di akhir file, walaupun sisa chunk kosong
+func (u *ParticipantUsecase) readImportChunks(
+	event *domain.Event,
+	opts *domain.ImportOptions,
+	content []byte,
+	fn func(chunk *importJobChunk) error,
+) error {
+	stream, err := openImportFile(bytes.NewReader(content), opts)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", opts.Format, err)
+	}
+	defer stream.Close()
+
+	duplicates := newFileDuplicates()
+	chunk := &importJobChunk{headers: stream.Headers}
+
+	for {
+		row, rowErr, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		chunk.read++
+
+		if rowErr == nil {
+			rowErr = u.checkImportRow(row, event.AttributeSchema, duplicates)
+		}
+
+		if rowErr != nil {
+			chunk.rowErrors = append(chunk.rowErrors, rowErr)
+		} else {
+			row.Participant.EventID = event.ID
+			chunk.rows = append(chunk.rows, row)
+		}
+
+		if chunk.read == domain.ImportChunkSize {
+			if err := fn(chunk); err != nil {
+				return err
+			}
+			chunk = &importJobChunk{headers: stream.Headers}
+		}
+	}
+
+	return fn(chunk)
+}
+
+// importChunk menyimpan satu chunk participant dalam satu transaction
+func (u *ParticipantUsecase) importChunk(ctx context.Context, event *domain.Event, job *domain.ImportJob, chunk []*csv.ParsedRow) error {
+	if len(chunk) == 0 {
+		return nil
+	}
+
+	participants := make([]*domain.Participant, 0, len(chunk))
+	for _, row := range chunk {
+		token, err := random.GenerateToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		row.Participant.QRToken = token
+		participants = append(participants, row.Participant)
+	}
+
+	result, err := u.participanRepo.Import(ctx, job.EventID, participants, job.Options.Mode, event.ParticipantCount)
+	if err != nil {
+		return fmt.Errorf("failed to import participants: %w", quotaError(event, err))
+	}
+
+	job.AddResult(result)
+	job.AddWarnings(importedWarnings(chunk, result)...)
+	for _, i := range result.Rejected {
+		job.AddErrors(registeredDuplicateError(chunk[i]))
+	}
+
+	return nil
+}
+
+// readImportFile membaca seluruh file import yang akan disimpan sebagai import job atau import preview
+// Return domain.ErrImportFileTooLarge jika file lebih besar dari domain.MaxImportFileSize
+func readImportFile(file io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(file, domain.MaxImportFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(content) > domain.MaxImportFileSize {
+		return nil, domain.ErrImportFileTooLarge
+	}
+
+	return content, nil
+}
+
+// emptyImportError error untuk file import tanpa row data sesuai format
+func emptyImportError(format string) error {
+	if format == domain.ImportFormatXLSX {
+		return domain.ErrEmptySheet
+	}
+
+	return domain.ErrEmptyCSV
+}
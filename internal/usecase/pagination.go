@@ -0,0 +1,24 @@
+package usecase
+
+// normalizePagination memvalidasi page dan limit lalu menghitung offset
+func normalizePagination(page, limit int) (int, int, int) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 10 // default 10 items per page
+	}
+
+	return page, limit, (page - 1) * limit
+}
+
+// totalPages menghitung jumlah halaman dari total data
+func totalPages(total, limit int) int {
+	pages := total / limit
+	if total%limit != 0 {
+		pages++
+	}
+
+	return pages
+}
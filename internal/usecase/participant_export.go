@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/pkg/csv"
+)
+
+// PrepareExport mengecek akses event dan menyiapkan kolom export participant
+// Dipanggil sebelum response ditulis supaya error masih bisa dikirim sebagai JSON
+func (u *ParticipantUsecase) PrepareExport(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	req *domain.ParticipantExportRequest,
+) (*domain.ParticipantExport, error) {
+	event, err := u.getOwnedEvent(ctx, organizerID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = domain.ExportFormatCSV
+	}
+
+	keys, err := u.participanRepo.AttributeKeys(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attribute di schema event lebih dulu sesuai urutan schema, walaupun belum ada participant yang mengisi
+	attributes := event.AttributeSchema.Columns(keys)
+
+	export := &domain.ParticipantExport{
+		EventID:    eventID,
+		Format:     format,
+		FileName:   fmt.Sprintf("%s-participants.%s", event.Slug, format),
+		Filter:     u.newParticipantFilter(req.CheckedIn, req.QRSent, req.Query, req.Sort, req.Order),
+		Attributes: attributes,
+	}
+
+	return export, nil
+}
+
+// WriteExport menulis participant ke w row per row langsung dari cursor database
+func (u *ParticipantUsecase) WriteExport(ctx context.Context, export *domain.ParticipantExport, w io.Writer) error {
+	writer, err := csv.NewExportWriter(w, export.Format)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	if err := writer.Write(export.Header()); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	err = u.participanRepo.Export(ctx, export.EventID, export.Filter, func(p *domain.Participant) error {
+		return writer.Write(export.Record(p))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export participants: %w", err)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/pkg/phone"
+)
+
+// NormalizePhones mengubah phone participant lama ke format E.164 dengan default region yang dikonfigurasi
+// Dijalankan sekali lewat command backfill supaya phone lama cocok dengan phone hasil import saat deteksi duplikat
+// Phone yang tidak bisa dinormalisasi ditandai dan dicatat di log supaya bisa diperbaiki organizer
+// Return jumlah phone yang dinormalisasi dan yang tidak valid
+func (u *ParticipantUsecase) NormalizePhones(ctx context.Context) (int, int, error) {
+	normalized, invalid := 0, 0
+	var afterID int64
+
+	for {
+		participants, err := u.participanRepo.GetNonE164Phones(ctx, afterID, backfillBatchSize)
+		if err != nil {
+			return normalized, invalid, fmt.Errorf("failed to get participant phones: %w", err)
+		}
+
+		if len(participants) == 0 {
+			return normalized, invalid, nil
+		}
+
+		var changes []domain.PhoneChange
+		var invalidPhones []*domain.Participant
+		for _, p := range participants {
+			e164, err := phone.Normalize(p.Phone, u.phoneRegion)
+			if err != nil {
+				log.Printf("Participant %d has phone %q that cannot be normalized for region %s", p.ID, p.Phone, u.phoneRegion)
+				invalidPhones = append(invalidPhones, p)
+				continue
+			}
+
+			changes = append(changes, domain.PhoneChange{ParticipantID: p.ID, OldPhone: p.Phone, NewPhone: e164})
+		}
+
+		if err := u.participanRepo.ReplacePhones(ctx, changes); err != nil {
+			return normalized, invalid, fmt.Errorf("failed to update participant phones: %w", err)
+		}
+
+		if err := u.participanRepo.MarkInvalidPhones(ctx, invalidPhones); err != nil {
+			return normalized, invalid, fmt.Errorf("failed to mark invalid participant phones: %w", err)
+		}
+
+		normalized += len(changes)
+		invalid += len(invalidPhones)
+		afterID = participants[len(participants)-1].ID
+	}
+}
@@ -1,101 +1,827 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/fzndps/eventcheck/internal/domain"
 	"github.com/fzndps/eventcheck/internal/domain/repository"
 	"github.com/fzndps/eventcheck/pkg/csv"
+	"github.com/fzndps/eventcheck/pkg/mailaddr"
+	"github.com/fzndps/eventcheck/pkg/phone"
 	"github.com/fzndps/eventcheck/pkg/random"
 )
 
 type ParticipantUsecase struct {
-	eventRepo      repository.EventRepository
-	participanRepo repository.ParticipantRepository
+	eventRepo         repository.EventRepository
+	participanRepo    repository.ParticipantRepository
+	organizerRepo     repository.OrganizerRepository
+	importPreviewRepo repository.ImportPreviewRepository
+	importJobRepo     repository.ImportJobRepository
+
+	// Region untuk nomor telepon yang ditulis tanpa kode negara
+	phoneRegion string
+
+	// Membangunkan import worker saat ada job baru
+	importWake chan struct{}
 }
 
-func NewParticipantUsecase(eventRepo repository.EventRepository, participanRepo repository.ParticipantRepository) *ParticipantUsecase {
+func NewParticipantUsecase(
+	eventRepo repository.EventRepository,
+	participanRepo repository.ParticipantRepository,
+	organizerRepo repository.OrganizerRepository,
+	importPreviewRepo repository.ImportPreviewRepository,
+	importJobRepo repository.ImportJobRepository,
+	phoneRegion string,
+) *ParticipantUsecase {
 	return &ParticipantUsecase{
-		eventRepo:      eventRepo,
-		participanRepo: participanRepo,
+		eventRepo:         eventRepo,
+		participanRepo:    participanRepo,
+		organizerRepo:     organizerRepo,
+		importPreviewRepo: importPreviewRepo,
+		importJobRepo:     importJobRepo,
+		phoneRegion:       phoneRegion,
+		importWake:        make(chan struct{}, 1),
 	}
 }
 
-// Menangani untuk upload CSV participants
+// Menangani untuk upload participants dari file CSV atau XLSX
+// Mode menentukan perlakuan untuk participant yang sudah terdaftar, default reject
+// Mapping dari request digabung dengan mapping default milik organizer
 func (u *ParticipantUsecase) UploadParticipants(
 	ctx context.Context,
 	organizerID int64,
 	eventID string,
-	csvReader io.Reader,
+	file io.Reader,
+	opts *domain.ImportOptions,
 ) (*domain.UploadParticipantsResponse, error) {
-	// Cek authorization untuk memastikan event milik organizer
-	isOwned, err := u.eventRepo.IsOwnedBy(ctx, eventID, organizerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get owner event: %w", err)
+	if err := normalizeImportOptions(opts); err != nil {
+		return nil, err
 	}
 
-	if !isOwned {
-		return nil, domain.ErrUnauthorizedAccess
+	// Cek authorization untuk memastikan event milik organizer
+	event, err := u.getOwnedEvent(ctx, organizerID, eventID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse CSV
-	participants, parseError, err := csv.ParseParticipants(csvReader)
+	prepared, err := u.prepareImport(ctx, organizerID, event, file, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse csd: %w", err)
+		return nil, err
 	}
 
 	// Generate unique QR code untuk setiap participant
+	participants := prepared.participants()
 	for _, p := range participants {
 		token, err := random.GenerateToken()
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate token: %w", err)
 		}
 		p.QRToken = token
-		p.EventID = eventID
 	}
 
-	// bulk insert ke database
-	var successCount int
-	var failedReason []string
-
+	result := &domain.ImportResult{}
 	if len(participants) > 0 {
-		err = u.participanRepo.BulkCreate(ctx, participants)
+		result, err = u.participanRepo.Import(ctx, eventID, participants, opts.Mode, event.ParticipantCount)
 		if err != nil {
-			return nil, fmt.Errorf("failed to bulk insert: %w", err)
+			return nil, fmt.Errorf("failed to import participants: %w", quotaError(event, err))
 		}
-		successCount = len(participants)
+	}
+
+	rowErrors := prepared.reportErrors(result)
+
+	failedReasons := make([]string, 0, len(rowErrors))
+	for _, rowErr := range rowErrors {
+		failedReasons = append(failedReasons, rowErr.Error())
 	}
 
 	res := &domain.UploadParticipantsResponse{
-		Success:       successCount,
-		Failed:        len(parseError),
-		FailedReasons: failedReason,
+		Mode:          opts.Mode,
+		Success:       result.Created + result.Updated,
+		Created:       result.Created,
+		Updated:       result.Updated,
+		Skipped:       result.Skipped,
+		Failed:        len(rowErrors),
+		FailedReasons: failedReasons,
+		Errors:        rowErrors,
+		Warnings:      prepared.reportWarnings(result),
+		Headers:       prepared.parsed.Headers,
 	}
 
 	return res, nil
 }
 
-// Menangani list partisipan
+// PreviewUpload menjalankan dry-run import: parse, validasi, cek duplikat dan kuota tanpa menyimpan participant
+// File disimpan dengan import token supaya organizer bisa mengkonfirmasi tanpa upload ulang
+// limit adalah jumlah row yang ditampilkan di preview
+func (u *ParticipantUsecase) PreviewUpload(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	file io.Reader,
+	opts *domain.ImportOptions,
+	limit int,
+) (*domain.ImportPreviewResponse, error) {
+	if err := normalizeImportOptions(opts); err != nil {
+		return nil, err
+	}
+
+	if limit < 1 || limit > domain.MaxImportPreviewRows {
+		limit = domain.DefaultImportPreviewRows
+	}
+
+	event, err := u.getOwnedEvent(ctx, organizerID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := readImportFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, err := u.prepareImport(ctx, organizerID, event, bytes.NewReader(content), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportResult{}
+	if participants := prepared.participants(); len(participants) > 0 {
+		result, err = u.participanRepo.PreviewImport(ctx, eventID, participants, opts.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview import: %w", err)
+		}
+	}
+
+	rowErrors := prepared.reportErrors(result)
+
+	token, err := random.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	now := time.Now()
+	preview := &domain.ImportPreview{
+		Token:       token,
+		EventID:     eventID,
+		OrganizerID: organizerID,
+		Options:     opts,
+		Content:     content,
+		ExpiresAt:   now.Add(domain.ImportPreviewTTL),
+		CreatedAt:   now,
+	}
+
+	if err := u.importPreviewRepo.Create(ctx, preview); err != nil {
+		return nil, fmt.Errorf("failed to save import preview: %w", err)
+	}
+
+	res := &domain.ImportPreviewResponse{
+		ImportToken: preview.Token,
+		ExpiresAt:   preview.ExpiresAt,
+		Mode:        opts.Mode,
+		Columns:     prepared.parsed.Columns.Describe(),
+		Rows:        make([]*domain.ImportPreviewRow, 0, limit),
+		TotalRows:   len(prepared.parsed.Rows) + len(prepared.parsed.Errors),
+		Created:     result.Created,
+		Updated:     result.Updated,
+		Skipped:     result.Skipped,
+		Failed:      len(rowErrors),
+		Errors:      rowErrors,
+		Warnings:    prepared.reportWarnings(result),
+	}
+
+	for i, row := range prepared.rows {
+		if len(res.Rows) == limit {
+			break
+		}
+
+		// Row yang ditolak sudah ada di error report
+		action := result.Actions[i]
+		if action == domain.ImportActionReject {
+			continue
+		}
+
+		p := row.Participant
+		res.Rows = append(res.Rows, &domain.ImportPreviewRow{
+			Row:        row.Number,
+			Action:     action,
+			Name:       p.Name,
+			Email:      p.Email,
+			Phone:      p.Phone,
+			Attributes: p.Attributes,
+		})
+	}
+
+	// Kuota tidak menggagalkan preview, organizer bisa membeli kuota sebelum konfirmasi
+	var quotaErr *domain.QuotaExceededError
+	if errors.As(quotaError(event, result.CheckQuota(event.ParticipantCount)), &quotaErr) {
+		res.QuotaExceeded = quotaErr
+	}
+
+	return res, nil
+}
+
+// ConfirmImport menjalankan import dari file yang sudah di dry-run dengan opsi yang sama
+// Import token hanya bisa dipakai sekali dan hanya untuk event yang sama, token bisa dipakai lagi jika import gagal
+// File di atas domain.ImportJobThreshold dijalankan sebagai import job, hanya salah satu response yang terisi
+func (u *ParticipantUsecase) ConfirmImport(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	token string,
+) (*domain.UploadParticipantsResponse, *domain.ImportJobResponse, error) {
+	preview, err := u.importPreviewRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrImportTokenNotFound
+		}
+		return nil, nil, err
+	}
+
+	if preview.EventID != eventID || preview.OrganizerID != organizerID || preview.IsExpired(time.Now()) {
+		return nil, nil, domain.ErrImportTokenNotFound
+	}
+
+	// Token di-claim sebelum import supaya confirm bersamaan tidak menjalankan import dua kali
+	if err := u.importPreviewRepo.Claim(ctx, token, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, domain.ErrImportTokenNotFound
+		}
+		return nil, nil, err
+	}
+
+	var res *domain.UploadParticipantsResponse
+	var job *domain.ImportJobResponse
+
+	content := bytes.NewReader(preview.Content)
+	if len(preview.Content) > domain.ImportJobThreshold {
+		job, err = u.SubmitImportJob(ctx, organizerID, eventID, content, preview.Options)
+	} else {
+		res, err = u.UploadParticipants(ctx, organizerID, eventID, content, preview.Options)
+	}
+
+	// Context request bisa sudah dibatalkan, token tetap harus dikembalikan atau dihapus
+	finishCtx := context.WithoutCancel(ctx)
+	if err != nil {
+		if releaseErr := u.importPreviewRepo.Release(finishCtx, token); releaseErr != nil {
+			log.Printf("Failed to release import token of event %s: %v", eventID, releaseErr)
+		}
+		return nil, nil, err
+	}
+
+	if err := u.importPreviewRepo.Delete(finishCtx, token); err != nil {
+		log.Printf("Failed to delete import token of event %s: %v", eventID, err)
+	}
+
+	return res, job, nil
+}
+
+// normalizeImportOptions mengisi opsi default dan memvalidasi opsi import
+func normalizeImportOptions(opts *domain.ImportOptions) error {
+	if opts.Mode == "" {
+		opts.Mode = domain.ImportModeReject
+	}
+
+	if opts.Format == "" {
+		opts.Format = domain.ImportFormatCSV
+	}
+
+	if err := domain.ValidateImportMode(opts.Mode); err != nil {
+		return err
+	}
+
+	return opts.Mapping.Validate()
+}
+
+// preparedImport row file import yang sudah divalidasi dan siap disimpan
+type preparedImport struct {
+	parsed    *csv.ParseResult
+	rows      []*csv.ParsedRow
+	rowErrors []*domain.ImportRowError
+}
+
+// prepareImport parse file lalu menolak row dengan phone atau attribute tidak valid
+// atau email dan phone yang sama di dalam file
+func (u *ParticipantUsecase) prepareImport(
+	ctx context.Context,
+	organizerID int64,
+	event *domain.Event,
+	file io.Reader,
+	opts *domain.ImportOptions,
+) (*preparedImport, error) {
+	if err := u.resolveImportMapping(ctx, organizerID, opts); err != nil {
+		return nil, err
+	}
+
+	// Parse file, row yang tidak valid dikumpulkan sebagai row error
+	parsed, err := parseImportFile(file, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", opts.Format, err)
+	}
+
+	prepared := &preparedImport{
+		parsed:    parsed,
+		rows:      make([]*csv.ParsedRow, 0, len(parsed.Rows)),
+		rowErrors: parsed.Errors,
+	}
+
+	duplicates := newFileDuplicates()
+	for _, row := range parsed.Rows {
+		if rowErr := u.checkImportRow(row, event.AttributeSchema, duplicates); rowErr != nil {
+			prepared.rowErrors = append(prepared.rowErrors, rowErr)
+			continue
+		}
+
+		row.Participant.EventID = event.ID
+		prepared.rows = append(prepared.rows, row)
+	}
+
+	return prepared, nil
+}
+
+// resolveImportMapping menggabungkan mapping dari request dengan mapping default milik organizer
+// Mapping yang dipakai disimpan di opts supaya konfirmasi dry-run dan import job memakai mapping yang sama
+func (u *ParticipantUsecase) resolveImportMapping(ctx context.Context, organizerID int64, opts *domain.ImportOptions) error {
+	savedMapping, err := u.organizerRepo.GetImportMapping(ctx, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to get import mapping: %w", err)
+	}
+
+	opts.Mapping = savedMapping.Merge(opts.Mapping)
+	return nil
+}
+
+// checkImportRow menormalisasi phone row ke E.164, memvalidasi attribute sesuai schema event
+// lalu mengecek duplikat di dalam file
+// Normalisasi dilakukan lebih dulu supaya 0812... dan +62812... dianggap nomor yang sama
+func (u *ParticipantUsecase) checkImportRow(row *csv.ParsedRow, schema domain.AttributeSchema, duplicates *fileDuplicates) *domain.ImportRowError {
+	normalized, err := phone.Normalize(row.Participant.Phone, u.phoneRegion)
+	if err != nil {
+		return &domain.ImportRowError{
+			Row:       row.Number,
+			Column:    domain.ParticipantFieldPhone,
+			Value:     row.Participant.Phone,
+			ErrorCode: domain.ImportErrorInvalidPhone,
+			Message:   "phone number is invalid",
+			Record:    row.Record,
+		}
+	}
+
+	row.Participant.Phone = normalized
+
+	attributes, err := schema.Apply(row.Participant.Attributes)
+	if err != nil {
+		return attributeImportError(row, err)
+	}
+	row.Participant.Attributes = attributes
+
+	return duplicates.Check(row)
+}
+
+// attributeImportError membuat row error dari attribute yang tidak sesuai schema event
+func attributeImportError(row *csv.ParsedRow, err error) *domain.ImportRowError {
+	rowErr := &domain.ImportRowError{
+		Row:       row.Number,
+		ErrorCode: domain.ImportErrorInvalidAttribute,
+		Message:   err.Error(),
+		Record:    row.Record,
+	}
+
+	var attrErr *domain.AttributeError
+	if errors.As(err, &attrErr) {
+		rowErr.Column = attrErr.Attribute
+		rowErr.Value = attrErr.Value
+	}
+
+	if errors.Is(err, domain.ErrAttributeRequired) {
+		rowErr.ErrorCode = domain.ImportErrorRequired
+	}
+
+	return rowErr
+}
+
+// fileDuplicates mencari row dengan email atau phone yang sama di dalam file, row pertama yang dipakai
+type fileDuplicates struct {
+	matcher  *domain.ParticipantMatcher
+	firstRow map[*domain.Participant]int
+}
+
+func newFileDuplicates() *fileDuplicates {
+	return &fileDuplicates{
+		matcher:  domain.NewParticipantMatcher(nil),
+		firstRow: make(map[*domain.Participant]int),
+	}
+}
+
+// Check return row error jika row sama dengan row sebelumnya, row yang bukan duplikat dicatat
+func (d *fileDuplicates) Check(row *csv.ParsedRow) *domain.ImportRowError {
+	if first := d.matcher.Match(row.Participant); first != nil {
+		return duplicateInFileError(row, first, d.firstRow[first])
+	}
+
+	d.matcher.Add(row.Participant)
+	d.firstRow[row.Participant] = row.Number
+
+	return nil
+}
+
+func (p *preparedImport) participants() []*domain.Participant {
+	participants := make([]*domain.Participant, 0, len(p.rows))
+	for _, row := range p.rows {
+		participants = append(participants, row.Participant)
+	}
+
+	return participants
+}
+
+// reportErrors menggabungkan row error dari file dengan participant yang ditolak
+// karena sudah terdaftar di event, diurutkan berdasarkan nomor row
+func (p *preparedImport) reportErrors(result *domain.ImportResult) []*domain.ImportRowError {
+	rowErrors := append([]*domain.ImportRowError{}, p.rowErrors...)
+
+	// Participant yang sudah terdaftar di event ditolak pada mode reject
+	for _, i := range result.Rejected {
+		rowErrors = append(rowErrors, registeredDuplicateError(p.rows[i]))
+	}
+
+	sortRowErrors(rowErrors)
+	return rowErrors
+}
+
+// reportWarnings mengumpulkan warning dari row yang dibuat atau diupdate oleh import
+func (p *preparedImport) reportWarnings(result *domain.ImportResult) []*domain.ImportRowWarning {
+	return importedWarnings(p.rows, result)
+}
+
+// importedWarnings return warning dari row yang action-nya create atau update,
+// row yang dilewati atau ditolak tidak perlu dicek organizer
+func importedWarnings(rows []*csv.ParsedRow, result *domain.ImportResult) []*domain.ImportRowWarning {
+	warnings := []*domain.ImportRowWarning{}
+	for i, row := range rows {
+		if i >= len(result.Actions) {
+			break
+		}
+
+		if action := result.Actions[i]; action == domain.ImportActionCreate || action == domain.ImportActionUpdate {
+			warnings = append(warnings, row.Warnings...)
+		}
+	}
+
+	return warnings
+}
+
+func sortRowErrors(rowErrors []*domain.ImportRowError) {
+	sort.SliceStable(rowErrors, func(i, j int) bool {
+		return rowErrors[i].Row < rowErrors[j].Row
+	})
+}
+
+// registeredDuplicateError membuat row error untuk participant yang sudah terdaftar di event
+func registeredDuplicateError(row *csv.ParsedRow) *domain.ImportRowError {
+	return &domain.ImportRowError{
+		Row:       row.Number,
+		Value:     row.Participant.Email,
+		ErrorCode: domain.ImportErrorDuplicate,
+		Message:   "participant with the same email or phone is already registered",
+		Record:    row.Record,
+	}
+}
+
+// openImportFile membuka file sesuai format untuk dibaca row per row
+func openImportFile(file io.Reader, opts *domain.ImportOptions) (*csv.Stream, error) {
+	switch opts.Format {
+	case domain.ImportFormatXLSX:
+		return csv.OpenXLSX(file, opts.Sheet, opts.Mapping)
+	case domain.ImportFormatCSV:
+		return csv.OpenCSV(file, opts.Charset, opts.Mapping)
+	default:
+		return nil, domain.ErrUnsupportedImportFormat
+	}
+}
+
+// parseImportFile parse file sesuai format, semua format memakai validasi row yang sama
+func parseImportFile(file io.Reader, opts *domain.ImportOptions) (*csv.ParseResult, error) {
+	switch opts.Format {
+	case domain.ImportFormatXLSX:
+		return csv.ParseXLSX(file, opts.Sheet, opts.Mapping)
+	case domain.ImportFormatCSV:
+		return csv.Parse(file, opts.Charset, opts.Mapping)
+	default:
+		return nil, domain.ErrUnsupportedImportFormat
+	}
+}
+
+// duplicateInFileError membuat row error untuk row yang email atau phone-nya sama dengan row sebelumnya
+func duplicateInFileError(row *csv.ParsedRow, first *domain.Participant, firstRow int) *domain.ImportRowError {
+	column, value := "phone", row.Participant.Phone
+	if strings.EqualFold(row.Participant.Email, first.Email) {
+		column, value = "email", row.Participant.Email
+	}
+
+	return &domain.ImportRowError{
+		Row:       row.Number,
+		Column:    column,
+		Value:     value,
+		ErrorCode: domain.ImportErrorDuplicateInFile,
+		Message:   fmt.Sprintf("%s is the same as row %d", column, firstRow),
+		Record:    row.Record,
+	}
+}
+
+// Menangani list partisipan dengan pagination, filter dan sort
 func (u *ParticipantUsecase) GetParticipantsByEvent(
 	ctx context.Context,
 	organizerID int64,
 	eventID string,
-) ([]*domain.Participant, error) {
+	req *domain.ParticipantListRequest,
+) (*domain.ParticipantListResponse, error) {
+	if err := u.authorizeEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+
+	page, limit, offset := normalizePagination(req.Page, req.Limit)
+
+	filter := u.newParticipantFilter(req.CheckedIn, req.QRSent, req.Query, req.Sort, req.Order)
+	filter.Limit = limit
+	filter.Offset = offset
+
+	participants, total, err := u.participanRepo.List(ctx, eventID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants by event ID %s: %w", eventID, err)
+	}
+
+	res := &domain.ParticipantListResponse{
+		Participants: participants,
+		Total:        total,
+		Page:         page,
+		Limit:        limit,
+		TotalPage:    totalPages(total, limit),
+	}
+
+	return res, nil
+}
+
+// CreateParticipant menambah satu participant ke event
+// Email disimpan lowercase, phone dalam format E.164 dan attribute divalidasi sesuai schema event
+// Participant dengan email atau phone yang sudah terdaftar di event ditolak dengan ErrParticipantAlreadyExists
+func (u *ParticipantUsecase) CreateParticipant(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	req *domain.CreateParticipantRequest,
+) (*domain.Participant, error) {
+	event, err := u.getOwnedEvent(ctx, organizerID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant := &domain.Participant{
+		EventID: eventID,
+		Name:    strings.TrimSpace(req.Name),
+		Email:   strings.TrimSpace(req.Email),
+		Phone:   strings.TrimSpace(req.Phone),
+	}
+
+	if err := participant.Validate(); err != nil {
+		return nil, err
+	}
+
+	if participant.Attributes, err = event.AttributeSchema.Apply(req.Attributes); err != nil {
+		return nil, err
+	}
+
+	if participant.Email, err = mailaddr.Normalize(participant.Email); err != nil {
+		return nil, err
+	}
+
+	if participant.Phone, err = phone.Normalize(participant.Phone, u.phoneRegion); err != nil {
+		return nil, err
+	}
+
+	token, err := random.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	participant.QRToken = token
+
+	// Disimpan lewat Import supaya kuota dan duplikat dicek selagi row event dikunci
+	result, err := u.participanRepo.Import(ctx, eventID, []*domain.Participant{participant}, domain.ImportModeReject, event.ParticipantCount)
+	if err != nil {
+		return nil, quotaError(event, err)
+	}
+
+	if len(result.Rejected) > 0 {
+		return nil, domain.ErrParticipantAlreadyExists
+	}
+
+	// Ambil ulang untuk mendapatkan ID dan nilai default dari database
+	created, err := u.participanRepo.GetByQRToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateParticipant mengubah name, email, phone atau attribute participant
+// Jika email berubah, status QR direset supaya ticket dikirim ulang ke email baru
+// Email dan phone baru dinormalisasi seperti saat import
+// Email yang sudah dipakai participant lain di event ditolak dengan ErrParticipantAlreadyExists
+func (u *ParticipantUsecase) UpdateParticipant(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	participantID int64,
+	req *domain.UpdateParticipantRequest,
+) (*domain.Participant, error) {
+	participant, err := u.getEventParticipant(ctx, organizerID, eventID, participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		participant.Name = name
+	}
+
+	if req.Phone != "" {
+		if participant.Phone, err = phone.Normalize(req.Phone, u.phoneRegion); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Email != "" {
+		email, err := mailaddr.Normalize(req.Email)
+		if err != nil {
+			return nil, err
+		}
+		participant.ChangeEmail(email)
+	}
+
+	if req.Attributes != nil {
+		event, err := u.getOwnedEvent(ctx, organizerID, eventID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Attribute dari request ditambahkan ke attribute yang sudah ada
+		attributes := domain.MergeAttributes(participant.Attributes, req.Attributes)
+		if participant.Attributes, err = event.AttributeSchema.Apply(attributes); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := participant.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.participanRepo.Update(ctx, participant); err != nil {
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// DeleteParticipant menghapus participant dari event
+func (u *ParticipantUsecase) DeleteParticipant(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	participantID int64,
+) error {
+	if _, err := u.getEventParticipant(ctx, organizerID, eventID, participantID); err != nil {
+		return err
+	}
+
+	return u.participanRepo.Delete(ctx, participantID)
+}
+
+// newParticipantFilter membuat filter list participant dari query organizer
+func (u *ParticipantUsecase) newParticipantFilter(checkedIn, qrSent *bool, query, sort, order string) *domain.ParticipantFilter {
+	filter := &domain.ParticipantFilter{
+		CheckedIn:   checkedIn,
+		QRSent:      qrSent,
+		Query:       strings.TrimSpace(query),
+		PhonePrefix: phone.SearchPrefix(query, u.phoneRegion),
+		SortBy:      sort,
+	}
+
+	if filter.SortBy == "" {
+		filter.SortBy = domain.ParticipantSortCreatedAt
+	}
+
+	// Nama default urut A-Z, waktu default terbaru lebih dulu
+	switch order {
+	case "asc":
+		filter.SortDesc = false
+	case "desc":
+		filter.SortDesc = true
+	default:
+		filter.SortDesc = filter.SortBy != domain.ParticipantSortName
+	}
+
+	return filter
+}
+
+// authorizeEvent memastikan event milik organizer
+func (u *ParticipantUsecase) authorizeEvent(ctx context.Context, organizerID int64, eventID string) error {
 	isOwned, err := u.eventRepo.IsOwnedBy(ctx, eventID, organizerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get owner event: %w", err)
+		return fmt.Errorf("failed to get owner event: %w", err)
 	}
 
 	if !isOwned {
+		return domain.ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+// GetImportMapping mengambil column mapping default import participant milik organizer
+func (u *ParticipantUsecase) GetImportMapping(ctx context.Context, organizerID int64) (domain.ColumnMapping, error) {
+	mapping, err := u.organizerRepo.GetImportMapping(ctx, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping == nil {
+		mapping = domain.ColumnMapping{}
+	}
+
+	return mapping, nil
+}
+
+// SaveImportMapping menyimpan column mapping default import participant milik organizer
+// Mapping kosong menghapus mapping yang tersimpan
+func (u *ParticipantUsecase) SaveImportMapping(
+	ctx context.Context,
+	organizerID int64,
+	req *domain.ImportMappingRequest,
+) (domain.ColumnMapping, error) {
+	if err := req.Mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Header disimpan lowercase supaya sama dengan mapping dari request upload
+	mapping := domain.ColumnMapping{}.Merge(req.Mapping)
+
+	if err := u.organizerRepo.UpdateImportMapping(ctx, organizerID, mapping); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+// getOwnedEvent mencari event dan memastikan event milik organizer
+func (u *ParticipantUsecase) getOwnedEvent(ctx context.Context, organizerID int64, eventID string) (*domain.Event, error) {
+	event, err := u.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.OrganizerID != organizerID {
 		return nil, domain.ErrUnauthorizedAccess
 	}
 
-	participants, err := u.participanRepo.GetByEventID(ctx, eventID)
+	return event, nil
+}
+
+// quotaError melengkapi error kuota dengan harga kuota tambahan yang perlu dibeli
+func quotaError(event *domain.Event, err error) error {
+	var quotaErr *domain.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		_, _, quotaErr.AdditionalPrice = domain.CapacityUpgrade(event.ParticipantCount, event.TotalPrice, quotaErr.AdditionalNeeded)
+	}
+
+	return err
+}
+
+// getEventParticipant mencari participant milik event organizer
+// Participant dari event lain diperlakukan sebagai tidak ditemukan
+func (u *ParticipantUsecase) getEventParticipant(
+	ctx context.Context,
+	organizerID int64,
+	eventID string,
+	participantID int64,
+) (*domain.Participant, error) {
+	if err := u.authorizeEvent(ctx, organizerID, eventID); err != nil {
+		return nil, err
+	}
+
+	participant, err := u.participanRepo.GetByID(ctx, participantID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get participants by event ID %s: %w", eventID, err)
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	if participant.EventID != eventID {
+		return nil, domain.ErrNotFound
 	}
 
-	return participants, nil
+	return participant, nil
 }
@@ -70,7 +70,7 @@ func (u *QREmailUsecae) SendQRCodes(ctx context.Context, organizerID int64, even
 
 	for _, participant := range participants {
 		// Generate QR code as PNG bytes (for CID embedding)
-		qrBytes, err := u.qrGenerator.GenerateQRCode(participant.QRToken, 256)
+		qrBytes, err := u.qrGenerator.GenerateTicketQRCode(event, participant, 256)
 		if err != nil {
 			log.Printf("Failed to generate QR for participant %d: %v", participant.ID, err)
 			emailsFailed++
@@ -145,7 +145,7 @@ func (u QREmailUsecae) ResendQRCode(ctx context.Context, organizerID int64, even
 	}
 
 	// generate qr code
-	qrByte, err := u.qrGenerator.GenerateQRCode(participant.QRToken, 256)
+	qrByte, err := u.qrGenerator.GenerateTicketQRCode(event, participant, 256)
 	if err != nil {
 		return fmt.Errorf("failed to generate QR code: %w", err)
 	}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/fzndps/eventcheck/config"
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/internal/domain/repository"
+	"github.com/fzndps/eventcheck/pkg/hash"
+	"github.com/fzndps/eventcheck/pkg/jwt"
+	"github.com/fzndps/eventcheck/pkg/limiter"
+	"github.com/fzndps/eventcheck/pkg/random"
+	"github.com/google/uuid"
+)
+
+// backfillBatchSize jumlah row yang diproses per batch saat backfill data lama
+const backfillBatchSize = 500
+
+type ScannerUsecase struct {
+	eventRepo     repository.EventRepository
+	jwtManager    *jwt.JWTManager
+	cfg           *config.Config
+	ipAttempts    *limiter.AttemptLimiter
+	eventAttempts *limiter.AttemptLimiter
+}
+
+func NewScannerUsecase(eventRepo repository.EventRepository, jwtManager *jwt.JWTManager, cfg *config.Config) *ScannerUsecase {
+	lockout := time.Duration(cfg.Scanner.LockoutMinutes) * time.Minute
+
+	return &ScannerUsecase{
+		eventRepo:     eventRepo,
+		jwtManager:    jwtManager,
+		cfg:           cfg,
+		ipAttempts:    limiter.NewAttemptLimiter(cfg.Scanner.MaxAttemptsPerIP, lockout, lockout),
+		eventAttempts: limiter.NewAttemptLimiter(cfg.Scanner.MaxAttemptsPerEvent, lockout, lockout),
+	}
+}
+
+// Login menukar slug event dan scanner PIN dengan token scanner session
+// Token hanya bisa dipakai untuk check-in dan lookup di event tersebut
+// Percobaan gagal dihitung per client IP dan per event + range IP, jika terlalu banyak akan dikunci
+func (u *ScannerUsecase) Login(ctx context.Context, req *domain.ScannerLoginRequest, clientIP string) (*domain.ScannerLoginResponse, error) {
+	ipKey := "ip:" + clientIP
+
+	if until, locked := u.ipAttempts.LockedUntil(ipKey); locked {
+		return nil, scannerLockedError(until)
+	}
+
+	event, err := u.eventRepo.GetBySlug(ctx, req.Slug)
+	if err != nil {
+		// Jangan bedakan slug tidak ada dengan PIN salah
+		if errors.Is(err, domain.ErrEventNotFound) {
+			return nil, u.registerFailure(ipKey, "")
+		}
+		return nil, fmt.Errorf("failed to get event by slug: %w", err)
+	}
+
+	// Counter event dipisah per range IP (/24 atau /64) supaya penyerang yang
+	// berganti IP dari jaringan lain tidak bisa mengunci staff di venue
+	eventKey := "event:" + event.ID + ":" + ipRange(clientIP)
+
+	if until, locked := u.eventAttempts.LockedUntil(eventKey); locked {
+		return nil, scannerLockedError(until)
+	}
+
+	if !checkScannerPIN(req.PIN, event.ScannerPINHash) {
+		return nil, u.registerFailure(ipKey, eventKey)
+	}
+
+	u.ipAttempts.Reset(ipKey)
+
+	sessionID := uuid.New().String()
+	token, expiresAt, err := u.jwtManager.GenerateScannerToken(event.ID, sessionID, event.ScannerPINVersion, u.cfg.Scanner.TokenExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate scanner token: %w", err)
+	}
+
+	res := &domain.ScannerLoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		Event:     event.ToScannerEvent(),
+	}
+
+	return res, nil
+}
+
+// RotatePIN membuat scanner PIN baru untuk event
+// Semua scanner session yang dibuat dengan PIN lama langsung tidak berlaku
+func (u *ScannerUsecase) RotatePIN(ctx context.Context, organizerID int64, eventID string) (*domain.RotateScannerPINResponse, error) {
+	isOwned, err := u.eventRepo.IsOwnedBy(ctx, eventID, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner event: %w", err)
+	}
+
+	if !isOwned {
+		return nil, domain.ErrUnauthorizedAccess
+	}
+
+	pin, pinHash, err := generateScannerPIN(u.cfg.Scanner.PINLength)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.eventRepo.UpdateScannerPIN(ctx, eventID, pinHash); err != nil {
+		return nil, fmt.Errorf("failed to update scanner PIN: %w", err)
+	}
+
+	// Lockout untuk event ini tidak relevan lagi setelah PIN diganti
+	u.eventAttempts.Reset("event:" + eventID)
+
+	return &domain.RotateScannerPINResponse{ScannerPIN: pin}, nil
+}
+
+// HashPlainPINs meng-hash scanner PIN event lama yang masih tersimpan sebagai plain text
+// Dijalankan sekali lewat command backfill, return jumlah PIN yang di-hash
+func (u *ScannerUsecase) HashPlainPINs(ctx context.Context) (int, error) {
+	hashed := 0
+	afterID := ""
+	for {
+		events, err := u.eventRepo.GetPlainScannerPINs(ctx, afterID, backfillBatchSize)
+		if err != nil {
+			return hashed, fmt.Errorf("failed to get plain scanner PINs: %w", err)
+		}
+
+		for _, event := range events {
+			pinHash, err := hash.HashPassword(event.ScannerPINHash)
+			if err != nil {
+				return hashed, fmt.Errorf("failed to hash scanner PIN: %w", err)
+			}
+
+			if err := u.eventRepo.ReplacePlainScannerPIN(ctx, event.ID, event.ScannerPINHash, pinHash); err != nil {
+				return hashed, fmt.Errorf("failed to update scanner PIN of event %s: %w", event.ID, err)
+			}
+			hashed++
+		}
+
+		if len(events) < backfillBatchSize {
+			return hashed, nil
+		}
+		afterID = events[len(events)-1].ID
+	}
+}
+
+// registerFailure mencatat percobaan PIN gagal untuk IP dan event
+func (u *ScannerUsecase) registerFailure(ipKey, eventKey string) error {
+	if until, locked := u.ipAttempts.Fail(ipKey); locked {
+		return scannerLockedError(until)
+	}
+
+	if eventKey != "" {
+		if until, locked := u.eventAttempts.Fail(eventKey); locked {
+			return scannerLockedError(until)
+		}
+	}
+
+	return domain.ErrInvalidScannerCredentials
+}
+
+// ipRange return prefix /24 untuk IPv4 dan /64 untuk IPv6
+// IP yang tidak valid dipakai apa adanya
+func ipRange(clientIP string) string {
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return clientIP
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(24, 32)).String() + "/24"
+	}
+
+	return ip.Mask(net.CIDRMask(64, 128)).String() + "/64"
+}
+
+func scannerLockedError(until time.Time) error {
+	return fmt.Errorf("%w (locked until %s)", domain.ErrScannerLocked, until.Format(time.RFC3339))
+}
+
+// generateScannerPIN membuat scanner PIN baru beserta bcrypt hash nya
+func generateScannerPIN(length int) (string, string, error) {
+	pin, err := random.GeneratePINWithLength(length)
+	if err != nil {
+		return "", "", fmt.Errorf("failed generate random PIN: %w", err)
+	}
+
+	pinHash, err := hash.HashPassword(pin)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to hash scanner PIN: %w", err)
+	}
+
+	return pin, pinHash, nil
+}
+
+// checkScannerPIN membandingkan PIN dengan bcrypt hash yang tersimpan
+// PIN plain text dari event lama di-hash dengan command backfill scanner-pins sebelum deploy
+func checkScannerPIN(pin, stored string) bool {
+	return hash.CheckPassword(pin, stored)
+}
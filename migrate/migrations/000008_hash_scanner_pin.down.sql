@@ -0,0 +1,4 @@
+-- scanner_pin sudah berisi bcrypt hash dan PIN aslinya tidak bisa dikembalikan,
+-- jadi kolom tetap VARCHAR(255). Mengubahnya ke CHAR(4) akan memotong hash
+ALTER TABLE events
+DROP COLUMN IF EXISTS scanner_pin_version;
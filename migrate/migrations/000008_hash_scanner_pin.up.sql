@@ -0,0 +1,4 @@
+-- scanner_pin sekarang menyimpan bcrypt hash, bukan PIN plain text
+ALTER TABLE events
+MODIFY COLUMN scanner_pin VARCHAR(255) NOT NULL,
+ADD COLUMN scanner_pin_version INT NOT NULL DEFAULT 1 AFTER scanner_pin;
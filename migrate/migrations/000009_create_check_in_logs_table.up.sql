@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS check_in_logs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    participant_id BIGINT UNSIGNED NULL,
+    qr_token VARCHAR(255) NULL,
+    action VARCHAR(20) NOT NULL,
+    result VARCHAR(30) NOT NULL,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_organizer_id BIGINT UNSIGNED NULL,
+    actor_session_id VARCHAR(64) NULL,
+    device_id VARCHAR(100) NULL,
+    gate VARCHAR(100) NULL,
+    scanned_at DATETIME NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE,
+    FOREIGN KEY (participant_id) REFERENCES participants(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_check_in_logs_event_id ON check_in_logs(event_id, scanned_at);
+CREATE INDEX idx_check_in_logs_participant_id ON check_in_logs(participant_id, scanned_at);
+
+-- Participant yang sudah check-in sebelum tabel ini ada dicatat sebagai log dari system
+INSERT INTO check_in_logs (event_id, participant_id, qr_token, action, result, actor_type, scanned_at)
+SELECT event_id, id, qr_token, 'check_in', 'accepted', 'system', checked_in_at
+FROM participants
+WHERE checked_in = TRUE AND checked_in_at IS NOT NULL;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_participants_is_inside ON participants;
+
+ALTER TABLE participants
+DROP COLUMN IF EXISTS entry_count,
+DROP COLUMN IF EXISTS is_inside;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS max_entries,
+DROP COLUMN IF EXISTS reentry_policy;
@@ -0,0 +1,12 @@
+ALTER TABLE events
+ADD COLUMN reentry_policy ENUM('single', 'unlimited', 'limited') NOT NULL DEFAULT 'single' AFTER scanner_pin_version,
+ADD COLUMN max_entries INT NOT NULL DEFAULT 0 AFTER reentry_policy;
+
+ALTER TABLE participants
+ADD COLUMN is_inside BOOLEAN NOT NULL DEFAULT FALSE AFTER checked_in_at,
+ADD COLUMN entry_count INT NOT NULL DEFAULT 0 AFTER is_inside;
+
+-- Participant yang sudah check-in dianggap masih di dalam dengan satu entry
+UPDATE participants SET is_inside = TRUE, entry_count = 1 WHERE checked_in = TRUE;
+
+CREATE INDEX idx_participants_is_inside ON participants(event_id, is_inside);
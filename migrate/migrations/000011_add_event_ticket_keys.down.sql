@@ -0,0 +1,3 @@
+ALTER TABLE events
+DROP COLUMN IF EXISTS ticket_private_key,
+DROP COLUMN IF EXISTS ticket_public_key;
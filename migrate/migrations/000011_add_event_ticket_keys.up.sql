@@ -0,0 +1,4 @@
+-- Key pair Ed25519 per event untuk QR signed, event lama tetap memakai QR token hex
+ALTER TABLE events
+ADD COLUMN ticket_public_key VARCHAR(64) NULL AFTER max_entries,
+ADD COLUMN ticket_private_key VARCHAR(64) NULL AFTER ticket_public_key;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_participants_name ON participants;
+
+ALTER TABLE check_in_logs
+DROP COLUMN IF EXISTS method;
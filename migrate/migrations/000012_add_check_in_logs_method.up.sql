@@ -0,0 +1,5 @@
+-- Membedakan check-in hasil scan QR dengan check-in manual dari pencarian
+ALTER TABLE check_in_logs
+ADD COLUMN method VARCHAR(20) NOT NULL DEFAULT 'qr' AFTER action;
+
+CREATE INDEX idx_participants_name ON participants(event_id, name);
@@ -0,0 +1,4 @@
+ALTER TABLE check_in_logs
+DROP COLUMN IF EXISTS reverted_by,
+DROP COLUMN IF EXISTS reverted_at,
+DROP COLUMN IF EXISTS reason;
@@ -0,0 +1,5 @@
+-- Undo tidak menghapus log, log yang dibatalkan ditandai dengan reverted_at
+ALTER TABLE check_in_logs
+ADD COLUMN reason VARCHAR(255) NULL AFTER gate,
+ADD COLUMN reverted_at DATETIME NULL AFTER reason,
+ADD COLUMN reverted_by BIGINT UNSIGNED NULL AFTER reverted_at;
@@ -0,0 +1,6 @@
+ALTER TABLE check_in_logs
+DROP COLUMN IF EXISTS window_override;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS check_in_closes_after,
+DROP COLUMN IF EXISTS check_in_opens_before;
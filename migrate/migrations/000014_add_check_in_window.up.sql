@@ -0,0 +1,7 @@
+-- Jam check-in dalam menit sebelum dan sesudah tanggal event, NULL berarti tidak dibatasi
+ALTER TABLE events
+ADD COLUMN check_in_opens_before INT NULL AFTER ticket_private_key,
+ADD COLUMN check_in_closes_after INT NULL AFTER check_in_opens_before;
+
+ALTER TABLE check_in_logs
+ADD COLUMN window_override BOOLEAN NOT NULL DEFAULT FALSE AFTER reverted_by;
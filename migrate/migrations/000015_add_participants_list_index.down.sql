@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_participants_event_created_at ON participants;
+DROP INDEX IF EXISTS idx_participants_event_checked_in_at ON participants;
@@ -0,0 +1,3 @@
+-- Index untuk sort list participant per event
+CREATE INDEX idx_participants_event_created_at ON participants(event_id, created_at);
+CREATE INDEX idx_participants_event_checked_in_at ON participants(event_id, checked_in_at);
@@ -0,0 +1,5 @@
+ALTER TABLE organizers
+DROP COLUMN IF EXISTS import_mapping;
+
+ALTER TABLE participants
+DROP COLUMN IF EXISTS attributes;
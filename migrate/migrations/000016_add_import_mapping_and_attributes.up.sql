@@ -0,0 +1,7 @@
+-- Kolom tambahan dari file import disimpan sebagai attribute participant
+ALTER TABLE participants
+ADD COLUMN attributes JSON NULL AFTER phone;
+
+-- Column mapping default untuk import participant milik organizer
+ALTER TABLE organizers
+ADD COLUMN import_mapping JSON NULL AFTER password_hash;
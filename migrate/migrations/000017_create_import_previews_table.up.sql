@@ -0,0 +1,13 @@
+-- File import yang sudah di dry-run, dipakai saat organizer mengkonfirmasi import dengan import token
+CREATE TABLE IF NOT EXISTS import_previews (
+    token VARCHAR(64) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    organizer_id BIGINT UNSIGNED NOT NULL,
+    options JSON NOT NULL,
+    content MEDIUMBLOB NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_import_previews_expires_at ON import_previews(expires_at);
@@ -0,0 +1,3 @@
+-- Warning untuk row yang tetap diimport, misalnya email yang kemungkinan salah ketik
+ALTER TABLE import_jobs
+ADD COLUMN warnings JSON NULL AFTER errors;
@@ -0,0 +1,3 @@
+-- Schema attribute participant yang didefinisikan organizer per event
+ALTER TABLE events
+ADD COLUMN attribute_schema JSON NULL AFTER check_in_closes_after;
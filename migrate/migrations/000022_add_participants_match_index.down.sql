@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_participants_event_email ON participants;
+DROP INDEX IF EXISTS idx_participants_event_phone ON participants;
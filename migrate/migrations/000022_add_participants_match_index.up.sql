@@ -0,0 +1,3 @@
+-- Index untuk mencari participant dengan email atau phone yang sama saat import
+CREATE INDEX idx_participants_event_email ON participants(event_id, email);
+CREATE INDEX idx_participants_event_phone ON participants(event_id, phone);
@@ -0,0 +1,3 @@
+-- Header file import, dipakai untuk membuat CSV row yang ditolak setelah isi file dihapus
+ALTER TABLE import_jobs
+ADD COLUMN headers JSON NULL AFTER options;
@@ -0,0 +1,3 @@
+-- Participant duplikat yang sudah digabung tidak bisa dipisahkan lagi, hanya index yang dikembalikan
+DROP INDEX IF EXISTS idx_participants_event_email ON participants;
+CREATE INDEX idx_participants_event_email ON participants(event_id, email);
@@ -0,0 +1,44 @@
+-- Email participant unik per event supaya create dan update bersamaan tidak membuat duplikat
+-- Duplikat yang sudah ada (misalnya dari upload CSV yang sama dua kali) digabung ke participant dengan id terkecil
+
+-- Status check-in dan pengiriman QR dari semua duplikat digabung ke participant yang dipertahankan
+UPDATE participants p
+JOIN (
+    SELECT event_id, email, MIN(id) AS keep_id,
+        MAX(checked_in) AS checked_in, MIN(checked_in_at) AS checked_in_at,
+        MAX(is_inside) AS is_inside, SUM(entry_count) AS entry_count,
+        MAX(qr_sent) AS qr_sent, MIN(qr_sent_at) AS qr_sent_at
+    FROM participants
+    GROUP BY event_id, email
+    HAVING COUNT(*) > 1
+) d ON p.id = d.keep_id
+SET p.checked_in = d.checked_in,
+    p.checked_in_at = d.checked_in_at,
+    p.is_inside = d.is_inside,
+    p.entry_count = d.entry_count,
+    p.qr_sent = d.qr_sent,
+    p.qr_sent_at = d.qr_sent_at;
+
+-- Log check-in duplikat dipindahkan ke participant yang dipertahankan supaya history tidak hilang
+UPDATE check_in_logs l
+JOIN participants p ON p.id = l.participant_id
+JOIN (
+    SELECT event_id, email, MIN(id) AS keep_id
+    FROM participants
+    GROUP BY event_id, email
+    HAVING COUNT(*) > 1
+) d ON d.event_id = p.event_id AND d.email = p.email
+SET l.participant_id = d.keep_id
+WHERE p.id <> d.keep_id;
+
+DELETE p FROM participants p
+JOIN (
+    SELECT event_id, email, MIN(id) AS keep_id
+    FROM participants
+    GROUP BY event_id, email
+    HAVING COUNT(*) > 1
+) d ON d.event_id = p.event_id AND d.email = p.email
+WHERE p.id <> d.keep_id;
+
+DROP INDEX IF EXISTS idx_participants_event_email ON participants;
+CREATE UNIQUE INDEX idx_participants_event_email ON participants(event_id, email);
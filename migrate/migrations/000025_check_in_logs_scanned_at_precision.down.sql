@@ -0,0 +1,2 @@
+ALTER TABLE check_in_logs
+MODIFY COLUMN scanned_at DATETIME NOT NULL;
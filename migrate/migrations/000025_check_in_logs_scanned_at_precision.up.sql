@@ -0,0 +1,3 @@
+-- scanned_at menyimpan microsecond supaya undo bisa membedakan scan di detik yang sama
+ALTER TABLE check_in_logs
+MODIFY COLUMN scanned_at DATETIME(6) NOT NULL;
@@ -0,0 +1,3 @@
+-- Phone lama yang gagal dinormalisasi ke E.164 dicatat supaya tidak diproses ulang oleh backfill phones
+ALTER TABLE participants
+ADD COLUMN invalid_phone VARCHAR(20) NULL AFTER phone;
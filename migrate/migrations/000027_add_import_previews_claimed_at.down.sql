@@ -0,0 +1,2 @@
+ALTER TABLE import_previews
+DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1,3 @@
+-- Import token ditandai sedang dipakai selama import berjalan, dikembalikan jika import gagal
+ALTER TABLE import_previews
+ADD COLUMN claimed_at DATETIME NULL AFTER expires_at;
@@ -0,0 +1,3 @@
+ALTER TABLE events
+DROP COLUMN IF EXISTS pending_price,
+DROP COLUMN IF EXISTS pending_participants;
@@ -0,0 +1,5 @@
+-- Kuota tambahan yang sudah dibeli tapi selisih harganya belum diverifikasi
+-- participant_count dan total_price hanya berisi kuota yang sudah dibayar
+ALTER TABLE events
+ADD COLUMN pending_participants INT NOT NULL DEFAULT 0 AFTER total_price,
+ADD COLUMN pending_price INT NOT NULL DEFAULT 0 AFTER pending_participants;
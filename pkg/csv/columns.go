@@ -0,0 +1,213 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+// headerAliases header bawaan yang dikenali untuk setiap field participant
+// Header dinormalisasi dulu dengan normalizeHeader sebelum dicocokkan
+var headerAliases = map[string]string{
+	"name":             domain.ParticipantFieldName,
+	"nama":             domain.ParticipantFieldName,
+	"nama lengkap":     domain.ParticipantFieldName,
+	"nama peserta":     domain.ParticipantFieldName,
+	"full name":        domain.ParticipantFieldName,
+	"fullname":         domain.ParticipantFieldName,
+	"participant name": domain.ParticipantFieldName,
+
+	"email":          domain.ParticipantFieldEmail,
+	"e mail":         domain.ParticipantFieldEmail,
+	"email address":  domain.ParticipantFieldEmail,
+	"alamat email":   domain.ParticipantFieldEmail,
+	"alamat e mail":  domain.ParticipantFieldEmail,
+	"surel":          domain.ParticipantFieldEmail,
+	"alamat surel":   domain.ParticipantFieldEmail,
+	"e mail address": domain.ParticipantFieldEmail,
+
+	"phone":           domain.ParticipantFieldPhone,
+	"phone number":    domain.ParticipantFieldPhone,
+	"mobile":          domain.ParticipantFieldPhone,
+	"mobile number":   domain.ParticipantFieldPhone,
+	"hp":              domain.ParticipantFieldPhone,
+	"no hp":           domain.ParticipantFieldPhone,
+	"nomor hp":        domain.ParticipantFieldPhone,
+	"handphone":       domain.ParticipantFieldPhone,
+	"no handphone":    domain.ParticipantFieldPhone,
+	"nomor handphone": domain.ParticipantFieldPhone,
+	"telp":            domain.ParticipantFieldPhone,
+	"no telp":         domain.ParticipantFieldPhone,
+	"telepon":         domain.ParticipantFieldPhone,
+	"no telepon":      domain.ParticipantFieldPhone,
+	"nomor telepon":   domain.ParticipantFieldPhone,
+	"whatsapp":        domain.ParticipantFieldPhone,
+	"wa":              domain.ParticipantFieldPhone,
+	"no wa":           domain.ParticipantFieldPhone,
+	"nomor wa":        domain.ParticipantFieldPhone,
+	"no whatsapp":     domain.ParticipantFieldPhone,
+	"nomor whatsapp":  domain.ParticipantFieldPhone,
+}
+
+// rejectedErrorColumn header kolom error di CSV row yang ditolak
+// Kolom ini diabaikan saat CSV tersebut diupload ulang
+const rejectedErrorColumn = "import_error"
+
+// Columns posisi kolom participant di file import
+type Columns struct {
+	headers    []string
+	fields     map[string]int // field participant -> index kolom
+	attributes map[int]string // index kolom -> key attribute
+}
+
+// ResolveColumns mencocokkan header file dengan field participant
+// Urutan prioritas: mapping dari organizer, lalu alias bawaan
+// Kolom yang tidak dipetakan disimpan sebagai attribute dengan header sebagai key
+func ResolveColumns(headers []string, mapping domain.ColumnMapping) (*Columns, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+
+	explicit := make(map[string]string, len(mapping))
+	for header, field := range mapping {
+		explicit[normalizeHeader(header)] = field
+	}
+
+	cols := &Columns{
+		headers:    headers,
+		fields:     make(map[string]int),
+		attributes: make(map[int]string),
+	}
+
+	// Mapping eksplisit diproses lebih dulu supaya alias tidak mengambil field yang sama
+	mapped := make(map[int]bool, len(headers))
+	for i, header := range headers {
+		field, ok := explicit[normalizeHeader(header)]
+		if !ok {
+			continue
+		}
+
+		mapped[i] = true
+		if field == domain.ParticipantFieldIgnore {
+			continue
+		}
+
+		if first, exists := cols.fields[field]; exists {
+			return nil, fmt.Errorf("%w: columns '%s' and '%s' are both mapped to %s",
+				domain.ErrInvalidColumnMapping, strings.TrimSpace(headers[first]), strings.TrimSpace(header), field)
+		}
+		cols.fields[field] = i
+	}
+
+	usedKeys := make(map[string]bool)
+	for i, header := range headers {
+		if mapped[i] {
+			continue
+		}
+
+		// Alias hanya dipakai untuk field yang belum terisi, kolom berikutnya menjadi attribute
+		if field, ok := headerAliases[normalizeHeader(header)]; ok {
+			if _, exists := cols.fields[field]; !exists {
+				cols.fields[field] = i
+				continue
+			}
+		}
+
+		key := strings.TrimSpace(header)
+		if key == "" || normalizeHeader(key) == normalizeHeader(rejectedErrorColumn) {
+			continue
+		}
+
+		// Header yang sama diberi nomor supaya tidak saling menimpa
+		for n := 2; usedKeys[key]; n++ {
+			key = fmt.Sprintf("%s %d", strings.TrimSpace(header), n)
+		}
+		usedKeys[key] = true
+		cols.attributes[i] = key
+	}
+
+	// Cek kolom yang dibutuhkan
+	requiredColumns := []string{domain.ParticipantFieldName, domain.ParticipantFieldEmail, domain.ParticipantFieldPhone}
+	for _, col := range requiredColumns {
+		if _, exists := cols.fields[col]; !exists {
+			return nil, fmt.Errorf("%w: '%s'", domain.ErrMissingColumn, col)
+		}
+	}
+
+	return cols, nil
+}
+
+// Header return header asli dari kolom yang dipakai untuk field
+func (c *Columns) Header(field string) string {
+	return strings.TrimSpace(c.headers[c.fields[field]])
+}
+
+// Describe return hasil deteksi setiap kolom sesuai urutan header
+func (c *Columns) Describe() []*domain.ImportColumn {
+	fieldByIndex := make(map[int]string, len(c.fields))
+	for field, i := range c.fields {
+		fieldByIndex[i] = field
+	}
+
+	columns := make([]*domain.ImportColumn, 0, len(c.headers))
+	for i, header := range c.headers {
+		column := &domain.ImportColumn{
+			Header: strings.TrimSpace(header),
+			Field:  domain.ParticipantFieldIgnore,
+		}
+
+		if field, ok := fieldByIndex[i]; ok {
+			column.Field = field
+		} else if key, ok := c.attributes[i]; ok {
+			column.Field = domain.ParticipantFieldAttribute
+			column.Attribute = key
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// Value mengambil nilai field participant dari record
+func (c *Columns) Value(record []string, field string) string {
+	return cellValue(record, c.fields[field])
+}
+
+// Attributes mengambil nilai kolom tambahan dari record, nilai kosong tidak disimpan
+func (c *Columns) Attributes(record []string) map[string]string {
+	var attributes map[string]string
+	for i, key := range c.attributes {
+		value := cellValue(record, i)
+		if value == "" {
+			continue
+		}
+
+		if attributes == nil {
+			attributes = make(map[string]string, len(c.attributes))
+		}
+		attributes[key] = value
+	}
+
+	return attributes
+}
+
+func cellValue(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+// normalizeHeader membuat header jadi lowercase dan mengganti tanda baca dengan spasi
+// sehingga "No. HP", "no_hp" dan "NO HP" dianggap sama
+func normalizeHeader(header string) string {
+	fields := strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,168 @@
+package csv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+func TestParse_HeaderAliases(t *testing.T) {
+	csvData := `Timestamp,Nama Lengkap,Alamat Email,No. HP,Instansi
+2026/10/01 10:00:00,Budi Santoso,budi@example.com,08123456789,Universitas Indonesia`
+
+	result, err := Parse(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 1 {
+		t.Fatalf("Expected 1 participant, got %d", len(result.Rows))
+	}
+
+	p := result.Rows[0].Participant
+	if p.Name != "Budi Santoso" || p.Email != "budi@example.com" || p.Phone != "08123456789" {
+		t.Errorf("Unexpected participant: %+v", p)
+	}
+
+	expected := map[string]string{
+		"Timestamp": "2026/10/01 10:00:00",
+		"Instansi":  "Universitas Indonesia",
+	}
+	for key, value := range expected {
+		if p.Attributes[key] != value {
+			t.Errorf("Expected attribute %s = %s, got %s", key, value, p.Attributes[key])
+		}
+	}
+}
+
+func TestParse_ColumnMapping(t *testing.T) {
+	csvData := `Peserta,Kontak,Surat,Catatan Internal,Ukuran Kaos
+Budi Santoso,08123456789,budi@example.com,VIP,L`
+
+	mapping := domain.ColumnMapping{
+		"peserta":          domain.ParticipantFieldName,
+		"KONTAK":           domain.ParticipantFieldPhone,
+		"Surat":            domain.ParticipantFieldEmail,
+		"Catatan Internal": domain.ParticipantFieldIgnore,
+	}
+
+	result, err := Parse(strings.NewReader(csvData), "", mapping)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	p := result.Rows[0].Participant
+	if p.Name != "Budi Santoso" || p.Email != "budi@example.com" || p.Phone != "08123456789" {
+		t.Errorf("Unexpected participant: %+v", p)
+	}
+
+	if len(p.Attributes) != 1 || p.Attributes["Ukuran Kaos"] != "L" {
+		t.Errorf("Expected only 'Ukuran Kaos' attribute, got %v", p.Attributes)
+	}
+}
+
+func TestParse_MappingOverridesAlias(t *testing.T) {
+	// "No HP" adalah alias phone, tapi mapping memilih kolom WhatsApp
+	csvData := `name,email,No HP,WhatsApp
+Budi,budi@example.com,0811,0812`
+
+	mapping := domain.ColumnMapping{"whatsapp": domain.ParticipantFieldPhone}
+
+	result, err := Parse(strings.NewReader(csvData), "", mapping)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	p := result.Rows[0].Participant
+	if p.Phone != "0812" {
+		t.Errorf("Expected phone from mapped column, got %s", p.Phone)
+	}
+
+	if p.Attributes["No HP"] != "0811" {
+		t.Errorf("Expected alias column to be kept as attribute, got %v", p.Attributes)
+	}
+}
+
+func TestParse_MappingErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		csvData  string
+		mapping  domain.ColumnMapping
+		expected error
+	}{
+		{
+			name:     "Unknown field",
+			csvData:  "name,email,phone\nBudi,budi@example.com,0811",
+			mapping:  domain.ColumnMapping{"name": "full_name"},
+			expected: domain.ErrInvalidColumnMapping,
+		},
+		{
+			name:     "Two columns mapped to the same field",
+			csvData:  "a,b,email,phone\nBudi,Santoso,budi@example.com,0811",
+			mapping:  domain.ColumnMapping{"a": "name", "b": "name"},
+			expected: domain.ErrInvalidColumnMapping,
+		},
+		{
+			name:     "Missing required column",
+			csvData:  "Nama,Email\nBudi,budi@example.com",
+			expected: domain.ErrMissingColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.csvData), "", tt.mapping)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeHeader(t *testing.T) {
+	tests := map[string]string{
+		"No. HP":         "no hp",
+		"  NO_HP ":       "no hp",
+		"E-mail":         "e mail",
+		"Nomor HP/WA":    "nomor hp wa",
+		"Alamat  Email:": "alamat email",
+	}
+
+	for input, expected := range tests {
+		if got := normalizeHeader(input); got != expected {
+			t.Errorf("normalizeHeader(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestColumns_Describe(t *testing.T) {
+	headers := []string{"Nama", "Email", "No HP", "Catatan", "Ukuran Kaos", "import_error"}
+	mapping := domain.ColumnMapping{"catatan": domain.ParticipantFieldIgnore}
+
+	cols, err := ResolveColumns(headers, mapping)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expected := []domain.ImportColumn{
+		{Header: "Nama", Field: domain.ParticipantFieldName},
+		{Header: "Email", Field: domain.ParticipantFieldEmail},
+		{Header: "No HP", Field: domain.ParticipantFieldPhone},
+		{Header: "Catatan", Field: domain.ParticipantFieldIgnore},
+		{Header: "Ukuran Kaos", Field: domain.ParticipantFieldAttribute, Attribute: "Ukuran Kaos"},
+		{Header: "import_error", Field: domain.ParticipantFieldIgnore},
+	}
+
+	got := cols.Describe()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d columns, got %d", len(expected), len(got))
+	}
+
+	for i, want := range expected {
+		if *got[i] != want {
+			t.Errorf("Column %d: expected %+v, got %+v", i, want, *got[i])
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// delimiters delimiter yang dikenali, koma dipakai jika jumlahnya sama
+var delimiters = []rune{',', ';', '\t'}
+
+// sniffSize jumlah byte awal file yang dipakai untuk deteksi charset dan delimiter
+const sniffSize = 64 << 10
+
+// decodeCSV mengubah file menjadi reader UTF-8 tanpa BOM, file dibaca bertahap dan tidak ditampung sekaligus
+// BOM selalu diutamakan, lalu charset dari organizer, lalu deteksi otomatis dari sniffSize byte pertama:
+// file yang bukan UTF-8 valid dianggap Windows-1252 (default Excel di Windows)
+func decodeCSV(reader io.Reader, charset string) (*bufio.Reader, error) {
+	buffered := bufio.NewReaderSize(reader, sniffSize)
+
+	head, err := buffered.Peek(sniffSize)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(head, utf8BOM):
+		buffered.Discard(len(utf8BOM))
+		return buffered, nil
+	case bytes.HasPrefix(head, utf16LEBOM), bytes.HasPrefix(head, utf16BEBOM):
+		return decodeReader(unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), buffered), nil
+	}
+
+	if charset = strings.TrimSpace(charset); charset != "" {
+		enc, err := htmlindex.Get(charset)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s'", domain.ErrUnsupportedCharset, charset)
+		}
+		return decodeReader(enc, buffered), nil
+	}
+
+	if validUTF8Prefix(head, len(head) == sniffSize) {
+		return buffered, nil
+	}
+
+	return decodeReader(charmap.Windows1252, buffered), nil
+}
+
+func decodeReader(enc encoding.Encoding, reader io.Reader) *bufio.Reader {
+	decoded := bufio.NewReaderSize(transform.NewReader(reader, enc.NewDecoder()), sniffSize)
+
+	if head, _ := decoded.Peek(len(utf8BOM)); bytes.Equal(head, utf8BOM) {
+		decoded.Discard(len(utf8BOM))
+	}
+
+	return decoded
+}
+
+// validUTF8Prefix sama seperti utf8.Valid, tapi rune terakhir yang terpotong di batas sniff tidak dianggap invalid
+func validUTF8Prefix(data []byte, truncated bool) bool {
+	if truncated {
+		for i := 1; i <= utf8.UTFMax && i <= len(data); i++ {
+			if start := len(data) - i; utf8.RuneStart(data[start]) {
+				if !utf8.FullRune(data[start:]) {
+					data = data[:start]
+				}
+				break
+			}
+		}
+	}
+
+	return utf8.Valid(data)
+}
+
+// detectDelimiter menentukan delimiter dari baris header
+// Baris "sep=;" yang ditulis Excel dipakai langsung dan dibuang dari reader
+func detectDelimiter(reader *bufio.Reader) (delimiter rune, hintLine bool) {
+	head, _ := reader.Peek(sniffSize)
+
+	line, _, _ := bytes.Cut(head, []byte("\n"))
+	header := bytes.TrimSuffix(line, []byte("\r"))
+
+	if hint, ok := bytes.CutPrefix(bytes.ToLower(header), []byte("sep=")); ok {
+		if r, size := utf8.DecodeRune(hint); size > 0 && size == len(hint) {
+			reader.Discard(min(len(line)+1, len(head)))
+			return r, true
+		}
+	}
+
+	delimiter = delimiters[0]
+	best := 0
+	for _, d := range delimiters {
+		if n := countOutsideQuotes(header, d); n > best {
+			delimiter, best = d, n
+		}
+	}
+
+	return delimiter, false
+}
+
+func countOutsideQuotes(line []byte, delimiter rune) int {
+	count := 0
+	quoted := false
+	for _, r := range string(line) {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == delimiter && !quoted:
+			count++
+		}
+	}
+
+	return count
+}
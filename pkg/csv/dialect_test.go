@@ -0,0 +1,213 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestParse_Delimiters(t *testing.T) {
+	tests := []struct {
+		name    string
+		csvData string
+	}{
+		{"comma", "name,email,phone\nJohn Doe,john@example.com,0812\n"},
+		{"semicolon", "Nama;Email;No HP\r\nJohn Doe;john@example.com;0812\r\n"},
+		{"tab", "name\temail\tphone\nJohn Doe\tjohn@example.com\t0812\n"},
+		{"quoted semicolon in comma file", "name,\"email;alt\",phone\nJohn Doe,john@example.com,0812\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := domain.ColumnMapping{"email;alt": domain.ParticipantFieldEmail}
+			result, err := Parse(strings.NewReader(tt.csvData), "", mapping)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+
+			if len(result.Rows) != 1 {
+				t.Fatalf("Expected 1 row, got %d rows and %v", len(result.Rows), result.Errors)
+			}
+
+			p := result.Rows[0].Participant
+			if p.Name != "John Doe" || p.Email != "john@example.com" || p.Phone != "0812" {
+				t.Errorf("Unexpected participant: %+v", p)
+			}
+		})
+	}
+}
+
+func TestParse_SepHintLine(t *testing.T) {
+	csvData := "sep=;\nname;email;phone\nJohn Doe;john@example.com;0812\nJane;invalid;0813\n"
+
+	result, err := Parse(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 1 || result.Rows[0].Number != 3 {
+		t.Fatalf("Expected 1 valid row at row 3, got %+v", result.Rows)
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0].Row != 4 {
+		t.Errorf("Expected row error at row 4, got %v", result.Errors)
+	}
+}
+
+func TestParse_UTF8BOM(t *testing.T) {
+	csvData := "\ufeffname;email;phone\nJohn Doe;john@example.com;0812\n"
+
+	result, err := Parse(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if result.Headers[0] != "name" || len(result.Rows) != 1 {
+		t.Errorf("Expected BOM to be stripped, got headers %q", result.Headers)
+	}
+}
+
+func TestParse_UTF16BOM(t *testing.T) {
+	enc := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	data, err := enc.NewEncoder().String("name\temail\tphone\nJosé\tjose@example.com\t0812\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Parse(strings.NewReader(data), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 1 || result.Rows[0].Participant.Name != "José" {
+		t.Errorf("Expected UTF-16 file to be decoded, got %+v", result.Rows)
+	}
+}
+
+func TestParse_Windows1252(t *testing.T) {
+	data, err := charmap.Windows1252.NewEncoder().String("name;email;phone;kota\nJosé Müller;jose@example.com;0812;Bogotá\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		charset string
+	}{
+		{"detected", ""},
+		{"override", "windows-1252"},
+		{"latin1 label", "iso-8859-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(strings.NewReader(data), tt.charset, nil)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+
+			p := result.Rows[0].Participant
+			if p.Name != "José Müller" || p.Attributes["kota"] != "Bogotá" {
+				t.Errorf("Unexpected participant: %+v", p)
+			}
+		})
+	}
+}
+
+func TestParse_UTF8RuneAtSniffBoundary(t *testing.T) {
+	// Rune multi byte yang terpotong di batas sniff tidak membuat file dianggap Windows-1252
+	header := "name,email,phone,catatan\n"
+	padding := strings.Repeat("a", sniffSize-len(header)-len("John,john@example.com,0812,")-1)
+	csvData := header + "John,john@example.com,0812," + padding + "é\n"
+
+	result, err := Parse(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 1 || !strings.HasSuffix(result.Rows[0].Participant.Attributes["catatan"], "aé") {
+		t.Errorf("Expected UTF-8 file to be kept, got %+v", result.Rows)
+	}
+}
+
+func TestParse_CharsetOverrideUTF8(t *testing.T) {
+	// Override utf-8 tidak mengubah teks UTF-8 yang valid
+	result, err := Parse(strings.NewReader("name,email,phone\nJosé,jose@example.com,0812\n"), "utf-8", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if result.Rows[0].Participant.Name != "José" {
+		t.Errorf("Expected José, got %q", result.Rows[0].Participant.Name)
+	}
+}
+
+func TestParse_UnsupportedCharset(t *testing.T) {
+	_, err := Parse(strings.NewReader("name,email,phone\n"), "klingon", nil)
+	if !errors.Is(err, domain.ErrUnsupportedCharset) {
+		t.Errorf("Expected ErrUnsupportedCharset, got %v", err)
+	}
+}
+
+func TestParse_RaggedRows(t *testing.T) {
+	csvData := `name,email,phone,company
+John Doe,john@example.com,0812
+Jane Smith,jane@example.com,0813,Globex,,
+Bob,bob@example.com,0814,Acme,Jakarta
+Alice,alice@example.com`
+
+	result, err := Parse(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	// Row yang kurang kolom dan row dengan cell kosong di akhir tetap diterima
+	if len(result.Rows) != 2 {
+		t.Fatalf("Expected 2 valid rows, got %d: %v", len(result.Rows), result.Errors)
+	}
+
+	if result.Rows[0].Participant.Attributes != nil {
+		t.Errorf("Expected no attributes for short row, got %v", result.Rows[0].Participant.Attributes)
+	}
+
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected 2 row errors, got %v", result.Errors)
+	}
+
+	if got := result.Errors[0]; got.Row != 4 || got.ErrorCode != domain.ImportErrorMalformedRow || got.Value != "Jakarta" {
+		t.Errorf("Expected malformed row 4 with extra value, got %+v", got)
+	}
+
+	if got := result.Errors[1]; got.Row != 5 || got.ErrorCode != domain.ImportErrorRequired || got.Column != "phone" {
+		t.Errorf("Expected required phone at row 5, got %+v", got)
+	}
+
+	// Kolom error tetap sejajar walaupun jumlah kolom row berbeda dengan header
+	var buf bytes.Buffer
+	if err := WriteRejectedRows(&buf, result.Headers, result.Errors); err != nil {
+		t.Fatal("Failed to write rejected rows:", err)
+	}
+
+	expected := `name,email,phone,company,import_error
+Bob,bob@example.com,0814,Acme,"row has 5 columns, header only has 4",Jakarta
+Alice,alice@example.com,,,phone is required
+`
+
+	if buf.String() != expected {
+		t.Errorf("Unexpected rejected CSV:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestParse_EmptyFile(t *testing.T) {
+	for _, csvData := range []string{"", "\ufeff"} {
+		_, err := Parse(strings.NewReader(csvData), "", nil)
+		if !errors.Is(err, domain.ErrEmptyCSV) {
+			t.Errorf("Expected ErrEmptyCSV for %q, got %v", csvData, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package csv
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// exportSheetName nama sheet file export XLSX
+const exportSheetName = "Participants"
+
+// ExportWriter menulis file export row per row
+// Flush menyelesaikan file setelah row terakhir, Close melepas resource dan selalu dipanggil
+type ExportWriter interface {
+	Write(record []string) error
+	Flush() error
+	Close() error
+}
+
+// NewExportWriter membuat ExportWriter untuk format csv atau xlsx
+func NewExportWriter(w io.Writer, format string) (ExportWriter, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return newCSVExportWriter(w)
+	case domain.ExportFormatXLSX:
+		return newXLSXExportWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// ExportContentType content type file export sesuai format
+func ExportContentType(format string) string {
+	if format == domain.ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+type csvExportWriter struct {
+	writer *csv.Writer
+}
+
+// BOM ditulis di awal supaya Excel membaca file sebagai UTF-8
+func newCSVExportWriter(w io.Writer) (*csvExportWriter, error) {
+	if _, err := w.Write(utf8BOM); err != nil {
+		return nil, err
+	}
+
+	return &csvExportWriter{writer: csv.NewWriter(w)}, nil
+}
+
+func (e *csvExportWriter) Write(record []string) error {
+	escaped := make([]string, len(record))
+	for i, value := range record {
+		escaped[i] = escapeFormula(value)
+	}
+
+	return e.writer.Write(escaped)
+}
+
+func (e *csvExportWriter) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExportWriter) Close() error {
+	return nil
+}
+
+// escapeFormula menambahkan tanda kutip di depan value yang akan dibaca sebagai formula oleh spreadsheet
+// Nomor telepon seperti +62 812-3456 tidak diubah
+func escapeFormula(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+
+	if strings.Trim(value, "+-0123456789 ()") == "" && strings.ContainsAny(value, "0123456789") {
+		return value
+	}
+
+	return "'" + value
+}
+
+// xlsxExportWriter menulis row lewat StreamWriter supaya row tidak ditahan di memory,
+// file baru ditulis ke w saat Flush
+type xlsxExportWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+
+	if err := file.SetSheetName("Sheet1", exportSheetName); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(exportSheetName)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &xlsxExportWriter{w: w, file: file, stream: stream}, nil
+}
+
+// Value ditulis sebagai text supaya nomor telepon tidak berubah menjadi angka
+func (e *xlsxExportWriter) Write(record []string) error {
+	e.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(record))
+	for i, value := range record {
+		values[i] = value
+	}
+
+	return e.stream.SetRow(cell, values)
+}
+
+func (e *xlsxExportWriter) Flush() error {
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+
+	return e.file.Write(e.w)
+}
+
+func (e *xlsxExportWriter) Close() error {
+	return e.file.Close()
+}
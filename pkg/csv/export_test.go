@@ -0,0 +1,120 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+func writeExport(t *testing.T, format string, records [][]string) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := NewExportWriter(&buf, format)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer writer.Close()
+
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	return &buf
+}
+
+func TestExportWriter_CSV(t *testing.T) {
+	buf := writeExport(t, domain.ExportFormatCSV, [][]string{
+		{"name", "email", "phone", "Instansi"},
+		{"John, Jr.", "john@example.com", "+62 812-3456-789", "=HYPERLINK(\"http://x\")"},
+		{"Jane", "jane@example.com", "0812", ""},
+	})
+
+	if !bytes.HasPrefix(buf.Bytes(), utf8BOM) {
+		t.Error("Expected CSV export to start with UTF-8 BOM")
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(buf.Bytes()[len(utf8BOM):])).ReadAll()
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+
+	john := records[1]
+	if john[0] != "John, Jr." || john[2] != "+62 812-3456-789" {
+		t.Errorf("Unexpected record: %v", john)
+	}
+
+	// Formula dari data participant tidak boleh dijalankan spreadsheet
+	if john[3] != "'=HYPERLINK(\"http://x\")" {
+		t.Errorf("Expected formula to be escaped, got %q", john[3])
+	}
+}
+
+func TestExportWriter_XLSX(t *testing.T) {
+	buf := writeExport(t, domain.ExportFormatXLSX, [][]string{
+		{"name", "email", "phone"},
+		{"John", "john@example.com", "081234567890"},
+	})
+
+	file, err := excelize.OpenReader(buf)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(exportSheetName)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(rows) != 2 || rows[1][0] != "John" {
+		t.Fatalf("Unexpected rows: %v", rows)
+	}
+
+	// Nomor telepon disimpan sebagai text, 0 di depan tidak hilang
+	cellType, err := file.GetCellType(exportSheetName, "C2")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if rows[1][2] != "081234567890" || cellType == excelize.CellTypeNumber {
+		t.Errorf("Expected phone as text, got %q (%v)", rows[1][2], cellType)
+	}
+}
+
+func TestExportWriter_UnsupportedFormat(t *testing.T) {
+	if _, err := NewExportWriter(&bytes.Buffer{}, "pdf"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestEscapeFormula(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"John":             "John",
+		"+6281234567890":   "+6281234567890",
+		"-":                "'-",
+		"=1+1":             "'=1+1",
+		"@SUM(A1)":         "'@SUM(A1)",
+		"+1 (555) 123":     "+1 (555) 123",
+		"-2+3+cmd|' /C'!0": "'-2+3+cmd|' /C'!0",
+	}
+
+	for input, expected := range tests {
+		if got := escapeFormula(input); got != expected {
+			t.Errorf("escapeFormula(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
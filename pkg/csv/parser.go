@@ -2,98 +2,404 @@ package csv
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/fzndps/eventcheck/pkg/mailaddr"
 )
 
-func ParseParticipants(reader io.Reader) ([]*domain.Participant, []string, error) {
-	csvReader := csv.NewReader(reader)
+// ParseResult hasil parse CSV participant
+type ParseResult struct {
+	Headers []string
+	Columns *Columns
+	Rows    []*ParsedRow
+	Errors  []*domain.ImportRowError
+}
+
+// ParsedRow row valid beserta nomor row dan isi aslinya
+type ParsedRow struct {
+	Number      int
+	Record      []string
+	Participant *domain.Participant
+
+	// Catatan untuk row yang tetap diimport, misalnya saran perbaikan domain email
+	Warnings []*domain.ImportRowWarning
+}
+
+// Participants return participant dari semua row valid
+func (r *ParseResult) Participants() []*domain.Participant {
+	participants := make([]*domain.Participant, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		participants = append(participants, row.Participant)
+	}
+
+	return participants
+}
+
+// ParseParticipants parse CSV participant dan return row valid beserta row yang ditolak
+func ParseParticipants(reader io.Reader) ([]*domain.Participant, []*domain.ImportRowError, error) {
+	result, err := Parse(reader, "", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.Participants(), result.Errors, nil
+}
+
+// Parse membaca CSV participant, setiap row yang ditolak dicatat sebagai domain.ImportRowError
+// Delimiter (koma, titik koma atau tab), BOM dan charset dideteksi otomatis,
+// charset opsional untuk memaksa encoding tertentu misalnya "windows-1252"
+// mapping opsional, header yang tidak ada di mapping dicocokkan dengan alias bawaan
+func Parse(reader io.Reader, charset string, mapping domain.ColumnMapping) (*ParseResult, error) {
+	stream, err := OpenCSV(reader, charset, mapping)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	return collect(stream, domain.ErrEmptyCSV)
+}
+
+// OpenCSV membaca header CSV dan return Stream untuk membaca row data satu per satu
+// Deteksi delimiter, BOM dan charset sama seperti Parse
+// CountRows hanya menghitung row jika reader bisa dibaca ulang, misalnya bytes.Reader
+func OpenCSV(reader io.Reader, charset string, mapping domain.ColumnMapping) (*Stream, error) {
+	csvReader, hintLine, err := newCSVReader(reader, charset)
+	if err != nil {
+		return nil, err
+	}
+
+	records := &csvRecords{reader: csvReader}
+	if hintLine {
+		records.row++ // baris sep= tetap dihitung supaya nomor row sama dengan di Excel
+	}
 
 	// Mmembaca header row
-	headers, err := csvReader.Read()
+	headers, _, err := records.Read()
+	if err == io.EOF {
+		return nil, domain.ErrEmptyCSV
+	}
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
 	}
 
-	// Validate headers
-	headerMap := make(map[string]int)
-	for i, header := range headers {
-		// Trim shitespace dan lowercase
-		header = strings.TrimSpace(strings.ToLower(header))
-		headerMap[header] = i
+	stream, err := newStream(headers, records, mapping)
+	if err != nil {
+		return nil, err
 	}
 
-	// Cek kolom yang dibutuhkan
-	requiredColumns := []string{"name", "email", "phone"}
-	for _, col := range requiredColumns {
-		if _, exists := headerMap[col]; !exists {
-			return nil, nil, fmt.Errorf("column '%s' not found in CSV", col)
+	file, ok := reader.(sizedReaderAt)
+	if !ok {
+		return stream, nil
+	}
+
+	// File dibaca ulang dari awal dengan reader terpisah supaya posisi stream tidak berubah
+	stream.count = func() (int, error) {
+		csvReader, _, err := newCSVReader(io.NewSectionReader(file, 0, file.Size()), charset)
+		if err != nil {
+			return 0, err
 		}
+		csvReader.ReuseRecord = true
+
+		// Row yang gagal dibaca tetap dihitung karena akan dilaporkan sebagai row error
+		total := -1 // header tidak dihitung
+		for {
+			_, err := csvReader.Read()
+			if err == io.EOF {
+				return max(total, 0), nil
+			}
+			if err != nil && !isParseError(err) {
+				return 0, fmt.Errorf("failed to read CSV: %w", err)
+			}
+			total++
+		}
+	}
+
+	return stream, nil
+}
+
+// sizedReaderAt file yang bisa dibaca ulang dari awal, misalnya bytes.Reader dan strings.Reader
+type sizedReaderAt interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// newCSVReader membuat csv.Reader dari file dengan charset dan delimiter yang sudah dideteksi
+// hintLine true jika baris "sep=" sudah dibuang dari reader
+func newCSVReader(reader io.Reader, charset string) (*csv.Reader, bool, error) {
+	decoded, err := decodeCSV(reader, charset)
+	if err != nil {
+		return nil, false, err
+	}
+
+	delimiter, hintLine := detectDelimiter(decoded)
+
+	csvReader := csv.NewReader(decoded)
+	csvReader.Comma = delimiter
+	csvReader.FieldsPerRecord = -1 // jumlah kolom dicek sendiri terhadap header
+
+	return csvReader, hintLine, nil
+}
+
+// isParseError return true untuk error format row CSV yang dilaporkan sebagai row error
+// Error lain, misalnya file gagal dibaca, menghentikan pembacaan file
+func isParseError(err error) bool {
+	var parseErr *csv.ParseError
+	return errors.As(err, &parseErr)
+}
+
+// recordReader sumber row data participant, row adalah nomor row di file
+// Return io.EOF jika semua row sudah dibaca
+type recordReader interface {
+	Read() (record []string, row int, err error)
+}
+
+// csvRecords membaca row dari CSV, nomor row mengikuti urutan record di file
+type csvRecords struct {
+	reader *csv.Reader
+	row    int
+}
+
+func (r *csvRecords) Read() ([]string, int, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, 0, err
+	}
+
+	// File yang gagal dibaca bukan kesalahan row, pembacaan dihentikan
+	if err != nil && !isParseError(err) {
+		return nil, 0, fmt.Errorf("%w: %v", domain.ErrInvalidCSVFormat, err)
+	}
+
+	r.row++
+	return record, r.row, err
+}
+
+// Stream membaca row data file import satu per satu dengan validasi yang sama untuk semua format
+// Dipakai untuk import besar supaya row tidak perlu ditampung sekaligus
+type Stream struct {
+	Headers []string
+	Columns *Columns
+
+	records recordReader
+	count   func() (int, error)
+	close   func() error
+}
+
+func newStream(headers []string, records recordReader, mapping domain.ColumnMapping) (*Stream, error) {
+	cols, err := ResolveColumns(headers, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stream{
+		Headers: headers,
+		Columns: cols,
+		records: records,
+	}, nil
+}
+
+// Next membaca row berikutnya, return row valid atau row error
+// Return io.EOF jika semua row sudah dibaca
+func (s *Stream) Next() (*ParsedRow, *domain.ImportRowError, error) {
+	record, rowNumber, err := s.records.Read()
+	if err == io.EOF {
+		return nil, nil, io.EOF
 	}
 
-	// Baca data rows
-	var participants []*domain.Participant
-	var errors []string
-	rowNumber := 1 // mulai dari 1 setelah header
+	if errors.Is(err, domain.ErrInvalidCSVFormat) {
+		return nil, nil, err
+	}
+
+	if err != nil {
+		return nil, &domain.ImportRowError{
+			Row:       rowNumber,
+			Value:     strings.Join(record, ","),
+			ErrorCode: domain.ImportErrorMalformedRow,
+			Message:   fmt.Sprintf("failed to read row: %v", err),
+			Record:    record,
+		}, nil
+	}
+
+	// Row yang kolomnya kurang dianggap berisi cell kosong,
+	// tapi row dengan nilai di luar kolom header kemungkinan bergeser sehingga ditolak
+	if extra := extraCells(s.Headers, record); len(extra) > 0 {
+		return nil, &domain.ImportRowError{
+			Row:       rowNumber,
+			Value:     strings.Join(extra, ","),
+			ErrorCode: domain.ImportErrorMalformedRow,
+			Message:   fmt.Sprintf("row has %d columns, header only has %d", len(record), len(s.Headers)),
+			Record:    record,
+		}, nil
+	}
+
+	address, rowErr := validateRow(s.Columns, record)
+	if rowErr != nil {
+		rowErr.Row = rowNumber
+		rowErr.Record = record
+		return nil, rowErr, nil
+	}
+
+	// ekstrak data dari row, nama kosong diisi display name dari kolom email
+	participant := &domain.Participant{
+		Name:       s.Columns.Value(record, domain.ParticipantFieldName),
+		Email:      address.Email,
+		Phone:      s.Columns.Value(record, domain.ParticipantFieldPhone),
+		Attributes: s.Columns.Attributes(record),
+	}
+
+	if participant.Name == "" {
+		participant.Name = address.Name
+	}
+
+	row := &ParsedRow{
+		Number:      rowNumber,
+		Record:      record,
+		Participant: participant,
+	}
+
+	if suggestion := mailaddr.Suggest(address.Email); suggestion != "" {
+		row.Warnings = append(row.Warnings, &domain.ImportRowWarning{
+			Row:         rowNumber,
+			Column:      s.Columns.Header(domain.ParticipantFieldEmail),
+			Value:       address.Email,
+			WarningCode: domain.ImportWarningEmailTypo,
+			Message:     fmt.Sprintf("did you mean %s?", suggestion),
+			Suggestion:  suggestion,
+		})
+	}
+
+	return row, nil, nil
+}
+
+// CountRows menghitung jumlah row data di file untuk menampilkan progress
+// Hasilnya perkiraan, row kosong di file xlsx ikut dihitung
+func (s *Stream) CountRows() (int, error) {
+	if s.count == nil {
+		return 0, nil
+	}
+
+	return s.count()
+}
+
+// Close menutup file yang dibaca oleh stream
+func (s *Stream) Close() error {
+	if s.close == nil {
+		return nil
+	}
+
+	return s.close()
+}
+
+// collect membaca semua row dari stream, emptyErr dikembalikan jika file tidak punya row data
+func collect(stream *Stream, emptyErr error) (*ParseResult, error) {
+	result := &ParseResult{Headers: stream.Headers, Columns: stream.Columns}
 
 	for {
-		record, err := csvReader.Read()
+		row, rowErr, err := stream.Next()
 		if err == io.EOF {
 			break
 		}
-
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("Rows %d: error failed read data - %v", rowNumber, err))
-			rowNumber++
+			return nil, err
+		}
+
+		if rowErr != nil {
+			result.Errors = append(result.Errors, rowErr)
 			continue
 		}
 
-		rowNumber++
+		result.Rows = append(result.Rows, row)
+	}
 
-		// ekstrak data dari row
-		name := strings.TrimSpace(record[headerMap["name"]])
-		email := strings.TrimSpace(record[headerMap["email"]])
-		phone := strings.TrimSpace(record[headerMap["phone"]])
+	if result.empty() {
+		return nil, emptyErr
+	}
 
-		// Validate data
-		if name == "" {
-			errors = append(errors, fmt.Sprintf("Rows %d: name empty", rowNumber))
-			continue
+	return result, nil
+}
+
+// extraCells return cell yang tidak kosong setelah kolom terakhir header
+func extraCells(headers, record []string) []string {
+	var extra []string
+	for i := len(headers); i < len(record); i++ {
+		if strings.TrimSpace(record[i]) != "" {
+			extra = append(extra, record[i])
 		}
+	}
+
+	return extra
+}
+
+func (r *ParseResult) empty() bool {
+	return len(r.Rows) == 0 && len(r.Errors) == 0
+}
+
+// validateRow mengecek kolom wajib dan format email, return error pertama yang ditemukan
+// Column di error memakai header asli dari file
+// Email dibaca sesuai RFC 5322, nama boleh kosong jika email berisi display name
+func validateRow(cols *Columns, record []string) (*mailaddr.Address, *domain.ImportRowError) {
+	email := cols.Value(record, domain.ParticipantFieldEmail)
+	address, emailErr := mailaddr.Parse(email)
 
-		if email == "" {
-			errors = append(errors, fmt.Sprintf("Rows %d: email empty", rowNumber))
+	required := []string{domain.ParticipantFieldName, domain.ParticipantFieldEmail, domain.ParticipantFieldPhone}
+
+	for _, field := range required {
+		if cols.Value(record, field) != "" {
 			continue
 		}
 
-		if phone == "" {
-			errors = append(errors, fmt.Sprintf("Rows %d: phone empty", rowNumber))
+		if field == domain.ParticipantFieldName && address != nil && address.Name != "" {
 			continue
 		}
 
-		// Basic email validasi
-		if !strings.Contains(email, "@") {
-			errors = append(errors, fmt.Sprintf("Rows %d: format email invalid", rowNumber))
-			continue
+		return nil, &domain.ImportRowError{
+			Column:    cols.Header(field),
+			ErrorCode: domain.ImportErrorRequired,
+			Message:   field + " is required",
 		}
+	}
 
-		participant := &domain.Participant{
-			Name:  name,
-			Email: email,
-			Phone: phone,
+	if emailErr != nil {
+		return nil, &domain.ImportRowError{
+			Column:    cols.Header(domain.ParticipantFieldEmail),
+			Value:     email,
+			ErrorCode: domain.ImportErrorInvalidEmail,
+			Message:   "email format is invalid",
 		}
+	}
+
+	return address, nil
+}
+
+// WriteRejectedRows menulis row yang ditolak sebagai CSV dengan header asli
+// ditambah kolom import_error, supaya bisa diperbaiki lalu diupload ulang
+func WriteRejectedRows(w io.Writer, headers []string, rowErrors []*domain.ImportRowError) error {
+	csvWriter := csv.NewWriter(w)
 
-		participants = append(participants, participant)
+	if err := csvWriter.Write(append(append([]string{}, headers...), rejectedErrorColumn)); err != nil {
+		return err
 	}
 
-	if len(participants) == 0 && len(errors) == 0 {
-		return nil, nil, domain.ErrEmptyCSV
+	for _, rowErr := range rowErrors {
+		// Row yang kolomnya kurang dilengkapi supaya kolom error tetap sejajar,
+		// cell di luar kolom header ditulis setelah kolom error
+		record := append([]string{}, rowErr.Record...)
+		for len(record) < len(headers) {
+			record = append(record, "")
+		}
+
+		row := append(append([]string{}, record[:len(headers)]...), rowErr.Message)
+		if err := csvWriter.Write(append(row, record[len(headers):]...)); err != nil {
+			return err
+		}
 	}
 
-	return participants, errors, nil
+	csvWriter.Flush()
+	return csvWriter.Error()
 }
 
 // Melakukan validasi format csv sebelum di parse
@@ -1,6 +1,7 @@
 package csv
 
 import (
+	"io"
 	"strings"
 	"testing"
 
@@ -133,6 +134,138 @@ Jane Smith,jane@example.com,08987654321`
 	}
 }
 
+func TestParse_EmailNormalization(t *testing.T) {
+	csvData := `name,email,phone
+John Doe,  John.Doe@Example.COM ,08123456789
+,Jane Smith <jane@example.com>,08987654321
+Bob,bob@gmial,08111222333
+Ani,ani@gmial.com,08111222444`
+
+	result, err := Parse(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 3 || len(result.Errors) != 1 {
+		t.Fatalf("Expected 3 rows and 1 error, got %d rows and %v", len(result.Rows), result.Errors)
+	}
+
+	if email := result.Rows[0].Participant.Email; email != "john.doe@example.com" {
+		t.Errorf("Expected lowercase trimmed email, got %q", email)
+	}
+
+	// Nama kosong diisi display name dari kolom email
+	jane := result.Rows[1].Participant
+	if jane.Name != "Jane Smith" || jane.Email != "jane@example.com" {
+		t.Errorf("Expected display name to be extracted, got %+v", jane)
+	}
+
+	// Domain tanpa TLD ditolak
+	if result.Errors[0].Row != 4 || result.Errors[0].ErrorCode != domain.ImportErrorInvalidEmail {
+		t.Errorf("Unexpected error: %+v", result.Errors[0])
+	}
+
+	// Domain yang salah ketik tetap diimport dengan warning
+	warnings := result.Rows[2].Warnings
+	if len(warnings) != 1 || warnings[0].Suggestion != "ani@gmail.com" || warnings[0].Row != 5 {
+		t.Errorf("Expected typo warning for row 5, got %+v", warnings)
+	}
+
+	if len(result.Rows[0].Warnings) != 0 {
+		t.Errorf("Expected no warning for valid domain, got %+v", result.Rows[0].Warnings)
+	}
+}
+
+func TestParseParticipants_RowErrors(t *testing.T) {
+	csvData := `name,email,phone
+,john@example.com,08123456789
+Jane Smith,janeexample.com,08987654321
+Bob Johnson,bob@example.com
+Alice,alice@example.com,08111222333`
+
+	reader := strings.NewReader(csvData)
+	participants, errors, err := ParseParticipants(reader)
+
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(participants) != 1 {
+		t.Errorf("Expected 1 valid participant, got %d", len(participants))
+	}
+
+	expected := []struct {
+		row    int
+		column string
+		value  string
+		code   string
+	}{
+		{2, "name", "", domain.ImportErrorRequired},
+		{3, "email", "janeexample.com", domain.ImportErrorInvalidEmail},
+		{4, "phone", "", domain.ImportErrorRequired},
+	}
+
+	if len(errors) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errors), errors)
+	}
+
+	for i, want := range expected {
+		got := errors[i]
+		if got.Row != want.row || got.Column != want.column || got.Value != want.value || got.ErrorCode != want.code {
+			t.Errorf("Error %d: expected {%d %s %q %s}, got {%d %s %q %s}",
+				i, want.row, want.column, want.value, want.code,
+				got.Row, got.Column, got.Value, got.ErrorCode)
+		}
+
+		if got.Message == "" {
+			t.Errorf("Error %d should have a message", i)
+		}
+	}
+}
+
+func TestWriteRejectedRows(t *testing.T) {
+	csvData := `name,email,phone,company
+,john@example.com,08123456789,Acme
+Jane Smith,janeexample.com,08987654321,Globex
+Bob Johnson,bob@example.com
+Alice,alice@example.com,08111222333,Initech`
+
+	result, err := Parse(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	var sb strings.Builder
+	if err := WriteRejectedRows(&sb, result.Headers, result.Errors); err != nil {
+		t.Fatal("Failed to write rejected rows:", err)
+	}
+
+	expected := `name,email,phone,company,import_error
+,john@example.com,08123456789,Acme,name is required
+Jane Smith,janeexample.com,08987654321,Globex,email format is invalid
+Bob Johnson,bob@example.com,,,phone is required
+`
+
+	if sb.String() != expected {
+		t.Errorf("Unexpected rejected CSV:\n%s\nexpected:\n%s", sb.String(), expected)
+	}
+
+	// CSV hasil perbaikan bisa diupload ulang, kolom import_error diabaikan
+	fixed := strings.Replace(sb.String(), ",john@example.com", "John Doe,john@example.com", 1)
+	participants, _, err := ParseParticipants(strings.NewReader(fixed))
+	if err != nil {
+		t.Fatal("Unexpected error re-parsing rejected CSV:", err)
+	}
+
+	if len(participants) != 1 || participants[0].Name != "John Doe" {
+		t.Fatalf("Expected fixed row to be accepted, got %v", participants)
+	}
+
+	if len(participants[0].Attributes) != 1 || participants[0].Attributes["company"] != "Acme" {
+		t.Errorf("Expected only company attribute, got %v", participants[0].Attributes)
+	}
+}
+
 func TestParseParticipants_EmptyCSV(t *testing.T) {
 	csvData := `name,email,phone`
 
@@ -262,3 +395,48 @@ Bob Johnson,bob@example.com,08111222333`
 		ParseParticipants(reader)
 	}
 }
+
+func TestOpenCSV_Stream(t *testing.T) {
+	csvData := `name,email,phone
+John Doe,john@example.com,0812
+Jane Smith,invalid,0813
+
+Bob,bob@example.com,0814`
+
+	stream, err := OpenCSV(strings.NewReader(csvData), "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer stream.Close()
+
+	total, err := stream.CountRows()
+	if err != nil || total != 3 {
+		t.Errorf("Expected 3 rows, got %d (%v)", total, err)
+	}
+
+	var rows []*ParsedRow
+	var rowErrors []*domain.ImportRowError
+	for {
+		row, rowErr, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+
+		if rowErr != nil {
+			rowErrors = append(rowErrors, rowErr)
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) != 2 || rows[0].Number != 2 || rows[1].Number != 4 {
+		t.Errorf("Expected valid rows 2 and 4, got %+v", rows)
+	}
+
+	if len(rowErrors) != 1 || rowErrors[0].Row != 3 {
+		t.Errorf("Expected row error at row 3, got %v", rowErrors)
+	}
+}
@@ -0,0 +1,153 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// ParseXLSX membaca participant dari file Excel (.xlsx) dengan validasi yang sama seperti Parse
+// sheet kosong berarti sheet pertama, nomor row mengikuti nomor row di Excel
+func ParseXLSX(reader io.Reader, sheet string, mapping domain.ColumnMapping) (*ParseResult, error) {
+	stream, err := OpenXLSX(reader, sheet, mapping)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	return collect(stream, domain.ErrEmptySheet)
+}
+
+// OpenXLSX membaca header sheet dan return Stream untuk membaca row data satu per satu
+// Stream harus di-Close setelah selesai dibaca
+func OpenXLSX(reader io.Reader, sheet string, mapping domain.ColumnMapping) (*Stream, error) {
+	file, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidXLSXFormat, err)
+	}
+
+	stream, err := openSheet(file, sheet, mapping)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func openSheet(file *excelize.File, sheet string, mapping domain.ColumnMapping) (*Stream, error) {
+	name, err := findSheet(file.GetSheetList(), sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := file.Rows(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidXLSXFormat, err)
+	}
+
+	records := &xlsxRecords{rows: rows}
+
+	// Row pertama yang tidak kosong dipakai sebagai header
+	headers, headerRow, err := records.Read()
+	if err == io.EOF {
+		rows.Close()
+		return nil, domain.ErrEmptySheet
+	}
+	if err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("failed to read XLSX: %w", err)
+	}
+
+	stream, err := newStream(headers, records, mapping)
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	// Dimensi sheet tidak selalu ditulis dengan benar oleh aplikasi selain Excel,
+	// jadi row dihitung dengan iterator terpisah tanpa membaca isi cell
+	stream.count = func() (int, error) {
+		counter, err := file.Rows(name)
+		if err != nil {
+			return 0, err
+		}
+		defer counter.Close()
+
+		total := 0
+		for counter.Next() {
+			total++
+		}
+
+		return max(total-headerRow, 0), counter.Error()
+	}
+
+	stream.close = func() error {
+		rows.Close()
+		return file.Close()
+	}
+
+	return stream, nil
+}
+
+// findSheet mencari nama sheet tanpa membedakan huruf besar kecil seperti di Excel
+func findSheet(sheets []string, sheet string) (string, error) {
+	sheet = strings.TrimSpace(sheet)
+	if sheet == "" {
+		if len(sheets) == 0 {
+			return "", domain.ErrEmptySheet
+		}
+		return sheets[0], nil
+	}
+
+	for _, name := range sheets {
+		if strings.EqualFold(name, sheet) {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: '%s'", domain.ErrSheetNotFound, sheet)
+}
+
+// xlsxRecords membaca row dari sheet, row yang semua cell-nya kosong dilewati
+type xlsxRecords struct {
+	rows *excelize.Rows
+	row  int
+	done bool
+}
+
+func (r *xlsxRecords) Read() ([]string, int, error) {
+	for r.rows.Next() {
+		r.row++
+
+		record, err := r.rows.Columns()
+		if err != nil {
+			return record, r.row, err
+		}
+
+		if !isBlankRecord(record) {
+			return record, r.row, nil
+		}
+	}
+
+	// Error dari sheet hanya dilaporkan sekali, pembacaan berikutnya selesai
+	if err := r.rows.Error(); err != nil && !r.done {
+		r.done = true
+		return nil, r.row + 1, err
+	}
+
+	return nil, 0, io.EOF
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
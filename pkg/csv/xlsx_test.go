@@ -0,0 +1,193 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/xuri/excelize/v2"
+)
+
+// newXLSX membuat file xlsx di memory, setiap sheet diisi row mulai dari A1
+func newXLSX(t *testing.T, sheets map[string][][]any, order ...string) *bytes.Buffer {
+	t.Helper()
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for i, name := range order {
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", name); err != nil {
+				t.Fatal(err)
+			}
+		} else if _, err := f.NewSheet(name); err != nil {
+			t.Fatal(err)
+		}
+
+		for r, row := range sheets[name] {
+			cell, _ := excelize.CoordinatesToCellName(1, r+1)
+			if err := f.SetSheetRow(name, cell, &row); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf
+}
+
+func TestParseXLSX_FirstSheet(t *testing.T) {
+	file := newXLSX(t, map[string][][]any{
+		"Peserta": {
+			{"Nama", "Email", "No HP", "Instansi"},
+			{"John Doe", "john@example.com", "08123456789", "ACME"},
+			{"Jane Smith", "jane@example.com", 6281234567890, nil},
+		},
+		"Lainnya": {
+			{"name", "email", "phone"},
+			{"Other", "other@example.com", "0800"},
+		},
+	}, "Peserta", "Lainnya")
+
+	result, err := ParseXLSX(file, "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 2 || len(result.Errors) != 0 {
+		t.Fatalf("Expected 2 rows and no errors, got %d rows and %v", len(result.Rows), result.Errors)
+	}
+
+	john := result.Rows[0].Participant
+	if john.Name != "John Doe" || john.Phone != "08123456789" || john.Attributes["Instansi"] != "ACME" {
+		t.Errorf("Unexpected participant: %+v", john)
+	}
+
+	// Nomor HP yang disimpan sebagai angka tidak boleh berubah menjadi notasi ilmiah
+	if phone := result.Rows[1].Participant.Phone; phone != "6281234567890" {
+		t.Errorf("Expected phone 6281234567890, got %q", phone)
+	}
+
+	if result.Rows[1].Number != 3 {
+		t.Errorf("Expected row number 3, got %d", result.Rows[1].Number)
+	}
+}
+
+func TestParseXLSX_ChosenSheet(t *testing.T) {
+	file := newXLSX(t, map[string][][]any{
+		"Sheet1":  {{"catatan"}},
+		"Peserta": {{"name", "email", "phone"}, {"John", "john@example.com", "0812"}},
+	}, "Sheet1", "Peserta")
+
+	result, err := ParseXLSX(file, "peserta", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 1 || result.Rows[0].Participant.Email != "john@example.com" {
+		t.Errorf("Expected participant from sheet Peserta, got %+v", result.Rows)
+	}
+}
+
+func TestParseXLSX_SheetNotFound(t *testing.T) {
+	file := newXLSX(t, map[string][][]any{
+		"Sheet1": {{"name", "email", "phone"}, {"John", "john@example.com", "0812"}},
+	}, "Sheet1")
+
+	_, err := ParseXLSX(file, "Peserta", nil)
+	if !errors.Is(err, domain.ErrSheetNotFound) {
+		t.Errorf("Expected ErrSheetNotFound, got %v", err)
+	}
+}
+
+func TestParseXLSX_RowErrorsUseSheetRowNumber(t *testing.T) {
+	file := newXLSX(t, map[string][][]any{
+		"Sheet1": {
+			{},
+			{"name", "email", "phone"},
+			{"John", "john@example.com", "0812"},
+			{},
+			{"Jane", "invalid-email", "0813"},
+			{"", "bob@example.com", "0814"},
+		},
+	}, "Sheet1")
+
+	result, err := ParseXLSX(file, "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if len(result.Rows) != 1 || result.Rows[0].Number != 3 {
+		t.Fatalf("Expected 1 valid row at row 3, got %+v", result.Rows)
+	}
+
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected 2 row errors, got %v", result.Errors)
+	}
+
+	if result.Errors[0].Row != 5 || result.Errors[0].ErrorCode != domain.ImportErrorInvalidEmail {
+		t.Errorf("Unexpected first error: %+v", result.Errors[0])
+	}
+
+	if result.Errors[1].Row != 6 || result.Errors[1].ErrorCode != domain.ImportErrorRequired {
+		t.Errorf("Unexpected second error: %+v", result.Errors[1])
+	}
+}
+
+func TestParseXLSX_MissingColumn(t *testing.T) {
+	file := newXLSX(t, map[string][][]any{
+		"Sheet1": {{"name", "email"}, {"John", "john@example.com"}},
+	}, "Sheet1")
+
+	_, err := ParseXLSX(file, "", nil)
+	if !errors.Is(err, domain.ErrMissingColumn) {
+		t.Errorf("Expected ErrMissingColumn, got %v", err)
+	}
+}
+
+func TestParseXLSX_EmptySheet(t *testing.T) {
+	file := newXLSX(t, map[string][][]any{
+		"Sheet1": {{"name", "email", "phone"}},
+	}, "Sheet1")
+
+	_, err := ParseXLSX(file, "", nil)
+	if !errors.Is(err, domain.ErrEmptySheet) {
+		t.Errorf("Expected ErrEmptySheet, got %v", err)
+	}
+}
+
+func TestParseXLSX_InvalidFile(t *testing.T) {
+	_, err := ParseXLSX(strings.NewReader("name,email,phone\n"), "", nil)
+	if !errors.Is(err, domain.ErrInvalidXLSXFormat) {
+		t.Errorf("Expected ErrInvalidXLSXFormat, got %v", err)
+	}
+}
+
+func TestOpenXLSX_CountRows(t *testing.T) {
+	file := newXLSX(t, map[string][][]any{
+		"Sheet1": {
+			{},
+			{"name", "email", "phone"},
+			{"John", "john@example.com", "0812"},
+			{"Jane", "jane@example.com", "0813"},
+			{"Bob", "bob@example.com", "0814"},
+		},
+	}, "Sheet1")
+
+	stream, err := OpenXLSX(file, "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	defer stream.Close()
+
+	total, err := stream.CountRows()
+	if err != nil || total != 3 {
+		t.Errorf("Expected 3 rows, got %d (%v)", total, err)
+	}
+}
@@ -13,6 +13,23 @@ type JWTClaims struct {
 	jwt.RegisteredClaims
 }
 
+// ScannerSubject menandai token milik scanner session, bukan organizer
+const ScannerSubject = "scanner"
+
+var (
+	ErrScannerTokenNotAllowed = errors.New("scanner token cannot access this resource")
+	ErrNotScannerToken        = errors.New("token is not a scanner token")
+)
+
+// ScannerClaims adalah claims untuk scanner session yang dibuat dari event scanner PIN
+// Token ini hanya berlaku untuk satu event
+// PINVersion dipakai untuk membatalkan session saat scanner PIN di rotate
+type ScannerClaims struct {
+	EventID    string `json:"event_id"`
+	PINVersion int    `json:"pin_version"`
+	jwt.RegisteredClaims
+}
+
 type JWTManager struct {
 	secretKey string
 }
@@ -71,6 +88,71 @@ func (m *JWTManager) ValidateToken(tokenString string) (*JWTClaims, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	// Token scanner tidak boleh dipakai sebagai token organizer
+	if claims.Subject == ScannerSubject {
+		return nil, ErrScannerTokenNotAllowed
+	}
+
+	return claims, nil
+}
+
+// GenerateScannerToken membuat token scanner session untuk satu event
+// sessionID disimpan sebagai jti supaya setiap session bisa dibedakan
+func (m *JWTManager) GenerateScannerToken(eventID, sessionID string, pinVersion, expiryHours int) (string, time.Time, error) {
+	if len(m.secretKey) == 0 {
+		return "", time.Time{}, errors.New("JWT secret not initialize")
+	}
+
+	expiresAt := time.Now().Add(time.Hour * time.Duration(expiryHours))
+
+	claims := &ScannerClaims{
+		EventID:    eventID,
+		PINVersion: pinVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   ScannerSubject,
+			ID:        sessionID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	tokenString, err := token.SignedString([]byte(m.secretKey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+// ValidateScannerToken mengecek token scanner session masih valid
+func (m *JWTManager) ValidateScannerToken(tokenString string) (*ScannerClaims, error) {
+	if len(m.secretKey) == 0 {
+		return nil, errors.New("JWT secret not initialize")
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &ScannerClaims{}, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(m.secretKey), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*ScannerClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if claims.Subject != ScannerSubject || claims.EventID == "" {
+		return nil, ErrNotScannerToken
+	}
+
 	return claims, nil
 }
 
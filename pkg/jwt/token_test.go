@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -63,3 +64,61 @@ func TestExpiredToken(t *testing.T) {
 
 	t.Log("Expired token rejected correctly")
 }
+
+func TestScannerTokenValidate(t *testing.T) {
+	manager := NewJWTManager(secretKey)
+
+	token, expiresAt, err := manager.GenerateScannerToken("event-123", "session-abc", 2, 12)
+	if err != nil {
+		t.Fatal("Failed to generate scanner token:", err)
+	}
+
+	if expiresAt.Before(time.Now()) {
+		t.Fatal("Scanner token expiry should be in the future")
+	}
+
+	claims, err := manager.ValidateScannerToken(token)
+	if err != nil {
+		t.Fatal("Failed to validate scanner token:", err)
+	}
+
+	if claims.EventID != "event-123" {
+		t.Fatalf("Expected event_id event-123, got %s", claims.EventID)
+	}
+
+	if claims.ID != "session-abc" {
+		t.Fatalf("Expected session id session-abc, got %s", claims.ID)
+	}
+
+	if claims.PINVersion != 2 {
+		t.Fatalf("Expected pin_version 2, got %d", claims.PINVersion)
+	}
+
+	t.Log("Scanner token validate successfully")
+}
+
+func TestScannerTokenRejectedAsOrganizerToken(t *testing.T) {
+	manager := NewJWTManager(secretKey)
+
+	token, _, _ := manager.GenerateScannerToken("event-123", "session-abc", 2, 12)
+
+	_, err := manager.ValidateToken(token)
+	if !errors.Is(err, ErrScannerTokenNotAllowed) {
+		t.Fatalf("Expected ErrScannerTokenNotAllowed, got %v", err)
+	}
+
+	t.Log("Scanner token rejected for organizer access")
+}
+
+func TestOrganizerTokenRejectedAsScannerToken(t *testing.T) {
+	manager := NewJWTManager(secretKey)
+
+	token, _ := manager.GenerateToken(1, "test@example.com", 1)
+
+	_, err := manager.ValidateScannerToken(token)
+	if !errors.Is(err, ErrNotScannerToken) {
+		t.Fatalf("Expected ErrNotScannerToken, got %v", err)
+	}
+
+	t.Log("Organizer token rejected for scanner access")
+}
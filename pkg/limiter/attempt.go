@@ -0,0 +1,103 @@
+// Package limiter untuk membatasi percobaan yang gagal (misalnya login PIN)
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepThreshold jumlah key sebelum entry yang sudah kadaluarsa dibersihkan
+const sweepThreshold = 1024
+
+// AttemptLimiter menghitung percobaan gagal per key dalam satu window
+// Jika jumlah gagal mencapai maxAttempts, key dikunci selama lockout
+// Data disimpan di memory sehingga hilang saat server restart
+type AttemptLimiter struct {
+	mu          sync.Mutex
+	maxAttempts int
+	window      time.Duration
+	lockout     time.Duration
+	entries     map[string]*attemptEntry
+	now         func() time.Time
+}
+
+type attemptEntry struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+func NewAttemptLimiter(maxAttempts int, window, lockout time.Duration) *AttemptLimiter {
+	return &AttemptLimiter{
+		maxAttempts: maxAttempts,
+		window:      window,
+		lockout:     lockout,
+		entries:     make(map[string]*attemptEntry),
+		now:         time.Now,
+	}
+}
+
+// LockedUntil return waktu berakhirnya lockout jika key sedang dikunci
+func (l *AttemptLimiter) LockedUntil(key string) (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, exists := l.entries[key]
+	if !exists {
+		return time.Time{}, false
+	}
+
+	if l.now().Before(entry.lockedUntil) {
+		return entry.lockedUntil, true
+	}
+
+	return time.Time{}, false
+}
+
+// Fail mencatat satu percobaan gagal untuk key
+// Return waktu berakhirnya lockout jika percobaan ini membuat key terkunci
+func (l *AttemptLimiter) Fail(key string) (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if len(l.entries) >= sweepThreshold {
+		l.sweep(now)
+	}
+
+	entry, exists := l.entries[key]
+	if !exists || now.Sub(entry.windowStart) > l.window {
+		entry = &attemptEntry{windowStart: now}
+		l.entries[key] = entry
+	}
+
+	entry.failures++
+
+	if entry.failures >= l.maxAttempts {
+		entry.lockedUntil = now.Add(l.lockout)
+		// Window baru dimulai setelah lockout selesai
+		entry.failures = 0
+		entry.windowStart = entry.lockedUntil
+		return entry.lockedUntil, true
+	}
+
+	return time.Time{}, false
+}
+
+// Reset menghapus catatan percobaan gagal untuk key
+func (l *AttemptLimiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, key)
+}
+
+// sweep menghapus entry yang window dan lockout nya sudah lewat
+func (l *AttemptLimiter) sweep(now time.Time) {
+	for key, entry := range l.entries {
+		if now.After(entry.lockedUntil) && now.Sub(entry.windowStart) > l.window {
+			delete(l.entries, key)
+		}
+	}
+}
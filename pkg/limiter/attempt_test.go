@@ -0,0 +1,84 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestLimiter(now *time.Time) *AttemptLimiter {
+	l := NewAttemptLimiter(3, 10*time.Minute, 15*time.Minute)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestAttemptLimiter_LocksAfterMaxAttempts(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+
+	for i := 0; i < 2; i++ {
+		if _, locked := l.Fail("ip:1.2.3.4"); locked {
+			t.Fatalf("Should not be locked after %d failures", i+1)
+		}
+	}
+
+	until, locked := l.Fail("ip:1.2.3.4")
+	if !locked {
+		t.Fatal("Expected key to be locked after 3 failures")
+	}
+
+	if !until.Equal(now.Add(15 * time.Minute)) {
+		t.Errorf("Expected lockout until %v, got %v", now.Add(15*time.Minute), until)
+	}
+
+	if _, locked := l.LockedUntil("ip:1.2.3.4"); !locked {
+		t.Error("LockedUntil should report key as locked")
+	}
+
+	// Key lain tidak ikut terkunci
+	if _, locked := l.LockedUntil("ip:5.6.7.8"); locked {
+		t.Error("Other key should not be locked")
+	}
+}
+
+func TestAttemptLimiter_LockoutExpires(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+
+	for i := 0; i < 3; i++ {
+		l.Fail("event:abc")
+	}
+
+	now = now.Add(16 * time.Minute)
+
+	if _, locked := l.LockedUntil("event:abc"); locked {
+		t.Error("Lockout should expire after lockout duration")
+	}
+}
+
+func TestAttemptLimiter_WindowResetsFailures(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+
+	l.Fail("ip:1.2.3.4")
+	l.Fail("ip:1.2.3.4")
+
+	// Lewat dari window, hitungan gagal dimulai ulang
+	now = now.Add(11 * time.Minute)
+
+	if _, locked := l.Fail("ip:1.2.3.4"); locked {
+		t.Error("Failures outside the window should not count")
+	}
+}
+
+func TestAttemptLimiter_Reset(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(&now)
+
+	l.Fail("ip:1.2.3.4")
+	l.Fail("ip:1.2.3.4")
+	l.Reset("ip:1.2.3.4")
+
+	if _, locked := l.Fail("ip:1.2.3.4"); locked {
+		t.Error("Reset should clear previous failures")
+	}
+}
@@ -0,0 +1,79 @@
+// Package mailaddr untuk validasi dan normalisasi alamat email participant
+// Semua pengecekan berjalan offline, domain tidak dicek ke DNS
+package mailaddr
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+// Address alamat email hasil parse
+type Address struct {
+	Email string // alamat email lowercase tanpa spasi
+	Name  string // display name, misalnya "Budi" dari "Budi <budi@gmail.com>"
+}
+
+// Parse membaca alamat email sesuai RFC 5322, termasuk format "Nama <email>"
+// Domain harus berupa nama domain dengan TLD, misalnya budi@gmial ditolak
+// Return domain.ErrInvalidEmail jika alamat tidak valid
+func Parse(raw string) (*Address, error) {
+	parsed, err := mail.ParseAddress(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, domain.ErrInvalidEmail
+	}
+
+	email := strings.ToLower(parsed.Address)
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 || !validDomain(email[at+1:]) {
+		return nil, domain.ErrInvalidEmail
+	}
+
+	return &Address{Email: email, Name: strings.TrimSpace(parsed.Name)}, nil
+}
+
+// Normalize return alamat email lowercase tanpa display name
+func Normalize(raw string) (string, error) {
+	address, err := Parse(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return address.Email, nil
+}
+
+// validDomain mengecek domain terdiri dari minimal dua label dan TLD berupa huruf
+// Domain literal seperti [127.0.0.1] tidak diterima
+func validDomain(host string) bool {
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return false
+			}
+		}
+	}
+
+	tld := labels[len(labels)-1]
+	if len(tld) < 2 {
+		return false
+	}
+
+	for _, r := range tld {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
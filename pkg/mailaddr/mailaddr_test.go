@@ -0,0 +1,103 @@
+package mailaddr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedEmail string
+		expectedName  string
+	}{
+		{"Plain address", "budi@gmail.com", "budi@gmail.com", ""},
+		{"Trim and lowercase", "  Budi.Santoso@Gmail.COM ", "budi.santoso@gmail.com", ""},
+		{"Display name", "Budi Santoso <budi@gmail.com>", "budi@gmail.com", "Budi Santoso"},
+		{"Quoted display name", `"Santoso, Budi" <BUDI@example.co.id>`, "budi@example.co.id", "Santoso, Budi"},
+		{"Subdomain", "panitia@mail.ui.ac.id", "panitia@mail.ui.ac.id", ""},
+		{"Plus tag", "budi+event@gmail.com", "budi+event@gmail.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := Parse(tt.input)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+
+			if address.Email != tt.expectedEmail || address.Name != tt.expectedName {
+				t.Errorf("Expected %s <%s>, got %s <%s>", tt.expectedName, tt.expectedEmail, address.Name, address.Email)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"budi",
+		"budi@",
+		"@gmail.com",
+		"budi@gmial",
+		"budi@gmail.c",
+		"budi@gmail..com",
+		"budi@-gmail.com",
+		"budi@[127.0.0.1]",
+		"budi gmail.com",
+		"budi@gmail.com, ani@gmail.com",
+	}
+
+	for _, input := range inputs {
+		if _, err := Parse(input); !errors.Is(err, domain.ErrInvalidEmail) {
+			t.Errorf("Parse(%q): expected ErrInvalidEmail, got %v", input, err)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := map[string]string{
+		"budi@gmial.com":     "budi@gmail.com",
+		"budi@gmai.com":      "budi@gmail.com",
+		"budi@gmail.con":     "budi@gmail.com",
+		"budi@gmail.co.id":   "budi@gmail.com",
+		"budi@yaho.co.id":    "budi@yahoo.co.id",
+		"budi@yahoo.co":      "budi@yahoo.com",
+		"budi@hotmial.com":   "budi@hotmail.com",
+		"budi@outlook.co.id": "",
+		"budi@gmail.com":     "",
+		"budi@ui.ac.id":      "",
+		"budi@mail.com":      "",
+		"budi@example.com":   "",
+		"budi":               "",
+	}
+
+	for email, expected := range tests {
+		if got := Suggest(email); got != expected {
+			t.Errorf("Suggest(%q) = %q, expected %q", email, got, expected)
+		}
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"gmail.com", "gmail.com", 0},
+		{"gmial.com", "gmail.com", 1},
+		{"gmai.com", "gmail.com", 1},
+		{"gmaill.com", "gmail.com", 1},
+		{"gnail.com", "gmail.com", 1},
+		{"yahoo.com", "gmail.com", 5},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
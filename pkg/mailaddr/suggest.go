@@ -0,0 +1,110 @@
+package mailaddr
+
+import "strings"
+
+// commonDomains domain email yang banyak dipakai participant
+// Domain yang mirip dengan salah satu domain ini dianggap salah ketik
+var commonDomains = []string{
+	"gmail.com",
+	"googlemail.com",
+	"yahoo.com",
+	"yahoo.co.id",
+	"ymail.com",
+	"rocketmail.com",
+	"hotmail.com",
+	"outlook.com",
+	"outlook.co.id",
+	"icloud.com",
+	"protonmail.com",
+	"live.com",
+	"msn.com",
+	"aol.com",
+	"gmx.com",
+	"mail.com",
+	"email.com",
+}
+
+// domainTypos salah ketik yang terlalu jauh untuk dideteksi dari jarak edit
+var domainTypos = map[string]string{
+	"gmail.co.id":  "gmail.com",
+	"gmail.id":     "gmail.com",
+	"gmal.co":      "gmail.com",
+	"gmial.co":     "gmail.com",
+	"yahoo.id":     "yahoo.co.id",
+	"yaho.co":      "yahoo.com",
+	"yahoo.co.com": "yahoo.com",
+	"hotmail.id":   "hotmail.com",
+	"outlook.id":   "outlook.com",
+}
+
+// Suggest return alamat email dengan domain yang benar jika domain email
+// kemungkinan salah ketik, misalnya budi@gmial.com menjadi budi@gmail.com
+// Return string kosong jika tidak ada saran
+func Suggest(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	local, host := email[:at], strings.ToLower(email[at+1:])
+
+	if suggestion := suggestDomain(host); suggestion != "" {
+		return local + "@" + suggestion
+	}
+
+	return ""
+}
+
+func suggestDomain(host string) string {
+	if correct, ok := domainTypos[host]; ok {
+		return correct
+	}
+
+	for _, known := range commonDomains {
+		if host == known {
+			return ""
+		}
+	}
+
+	// Domain yang hanya berbeda satu huruf (kurang, lebih, salah atau tertukar)
+	for _, known := range commonDomains {
+		if editDistance(host, known) == 1 {
+			return known
+		}
+	}
+
+	return ""
+}
+
+// editDistance menghitung jarak Damerau-Levenshtein (optimal string alignment),
+// huruf yang tertukar posisinya dihitung satu perubahan
+func editDistance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,77 @@
+// Package phone untuk normalisasi nomor telepon participant ke format E.164
+package phone
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+	"github.com/nyaruka/phonenumbers"
+)
+
+// DefaultRegion region nomor yang ditulis tanpa kode negara, misalnya 0812...
+const DefaultRegion = "ID"
+
+// minSearchDigits jumlah digit minimal query pencarian yang dianggap awalan nomor telepon
+const minSearchDigits = 3
+
+// IsSupportedRegion return true jika region (kode negara ISO 3166, misalnya ID) dikenal
+func IsSupportedRegion(region string) bool {
+	return phonenumbers.GetCountryCodeForRegion(strings.ToUpper(region)) != 0
+}
+
+// Normalize mengubah nomor telepon menjadi format E.164, misalnya +6281234567890
+// Nomor tanpa kode negara dibaca sebagai nomor di region
+// Return domain.ErrInvalidPhone jika nomor tidak valid
+func Normalize(raw, region string) (string, error) {
+	number, err := phonenumbers.Parse(strings.TrimSpace(raw), strings.ToUpper(region))
+	if err != nil || !phonenumbers.IsValidNumber(number) {
+		return "", domain.ErrInvalidPhone
+	}
+
+	return phonenumbers.Format(number, phonenumbers.E164), nil
+}
+
+// SearchPrefix mengubah awalan nomor yang diketik organizer menjadi awalan E.164,
+// misalnya 0812 menjadi +62812, supaya pencarian cocok dengan nomor yang sudah dinormalisasi
+// Return string kosong jika query bukan awalan nomor telepon
+func SearchPrefix(query, region string) string {
+	var digits strings.Builder
+	for _, r := range query {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.' || (r == '+' && digits.Len() == 0):
+		default:
+			return ""
+		}
+	}
+
+	prefix := digits.String()
+	if len(prefix) < minSearchDigits {
+		return ""
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(query), "+") {
+		return "+" + prefix
+	}
+
+	region = strings.ToUpper(region)
+	countryCode := phonenumbers.GetCountryCodeForRegion(region)
+	if countryCode == 0 {
+		return ""
+	}
+
+	// Awalan nasional (0 di Indonesia) diganti kode negara, awalan yang sudah
+	// diawali kode negara (62812) dipakai apa adanya
+	code := strconv.Itoa(countryCode)
+	if national := phonenumbers.GetNddPrefixForRegion(region, true); national != "" && strings.HasPrefix(prefix, national) {
+		return "+" + code + strings.TrimPrefix(prefix, national)
+	}
+
+	if strings.HasPrefix(prefix, code) {
+		return "+" + prefix
+	}
+
+	return "+" + code + prefix
+}
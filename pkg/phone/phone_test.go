@@ -0,0 +1,78 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fzndps/eventcheck/internal/domain"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		region   string
+		expected string
+	}{
+		{"National format", "0812-3456-7890", "ID", "+6281234567890"},
+		{"International with spaces", "+62 812 3456 7890", "ID", "+6281234567890"},
+		{"Country code without plus", "6281234567890", "ID", "+6281234567890"},
+		{"Without national prefix", "81234567890", "ID", "+6281234567890"},
+		{"Landline", "(021) 5551234", "ID", "+62215551234"},
+		{"Other country", "+1 415 555 2671", "ID", "+14155552671"},
+		{"Lowercase region", "0812 3456 7890", "id", "+6281234567890"},
+		{"Other default region", "(415) 555-2671", "US", "+14155552671"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.input, tt.region)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+
+			if got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalize_Invalid(t *testing.T) {
+	for _, input := range []string{"", "12345", "0812345", "08123456789012345", "not a phone"} {
+		if _, err := Normalize(input, DefaultRegion); !errors.Is(err, domain.ErrInvalidPhone) {
+			t.Errorf("Normalize(%q): expected ErrInvalidPhone, got %v", input, err)
+		}
+	}
+}
+
+func TestIsSupportedRegion(t *testing.T) {
+	if !IsSupportedRegion("ID") || !IsSupportedRegion("sg") {
+		t.Error("Expected ID and SG to be supported")
+	}
+
+	if IsSupportedRegion("XX") || IsSupportedRegion("") {
+		t.Error("Expected unknown region to be unsupported")
+	}
+}
+
+func TestSearchPrefix(t *testing.T) {
+	tests := map[string]string{
+		"0812":      "+62812",
+		"0812-34":   "+6281234",
+		"62812":     "+62812",
+		"+62 812":   "+62812",
+		"812":       "+62812",
+		"+1 415":    "+1415",
+		"08":        "",
+		"budi":      "",
+		"0812abc":   "",
+		"budi@mail": "",
+	}
+
+	for query, expected := range tests {
+		if got := SearchPrefix(query, DefaultRegion); got != expected {
+			t.Errorf("SearchPrefix(%q) = %q, expected %q", query, got, expected)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Package pubsub adalah hub publish/subscribe in-process per topic
+// Dipakai untuk mengirim update realtime ke banyak subscriber (misalnya dashboard SSE)
+package pubsub
+
+import "sync"
+
+// Hub menyimpan subscriber per topic
+// Publish tidak pernah blocking: jika buffer subscriber penuh, message untuk
+// subscriber tersebut dibuang supaya subscriber yang lambat tidak menahan publisher
+type Hub struct {
+	mu     sync.RWMutex
+	topics map[string]map[*Subscription]struct{}
+}
+
+// Subscription satu subscriber pada sebuah topic
+type Subscription struct {
+	hub   *Hub
+	topic string
+	ch    chan any
+	once  sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		topics: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe mendaftarkan subscriber baru pada topic
+// Subscriber wajib memanggil Close setelah selesai
+func (h *Hub) Subscribe(topic string, buffer int) *Subscription {
+	sub := &Subscription{
+		hub:   h,
+		topic: topic,
+		ch:    make(chan any, buffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[*Subscription]struct{})
+	}
+	h.topics[topic][sub] = struct{}{}
+
+	return sub
+}
+
+// Publish mengirim message ke semua subscriber topic
+// Return jumlah subscriber yang menerima message
+func (h *Hub) Publish(topic string, msg any) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	delivered := 0
+	for sub := range h.topics[topic] {
+		select {
+		case sub.ch <- msg:
+			delivered++
+		default:
+			// Buffer penuh, message untuk subscriber ini dibuang
+		}
+	}
+
+	return delivered
+}
+
+// HasSubscribers return true jika topic punya minimal satu subscriber
+// Dipakai untuk melewati pekerjaan mahal saat tidak ada yang mendengarkan
+func (h *Hub) HasSubscribers(topic string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.topics[topic]) > 0
+}
+
+// C channel untuk menerima message, ditutup saat Close dipanggil
+func (s *Subscription) C() <-chan any {
+	return s.ch
+}
+
+// Close melepas subscriber dari hub, aman dipanggil berkali-kali
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		defer s.hub.mu.Unlock()
+
+		subs := s.hub.topics[s.topic]
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(s.hub.topics, s.topic)
+		}
+
+		close(s.ch)
+	})
+}
@@ -0,0 +1,101 @@
+package pubsub
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestHub_PublishToSubscribers(t *testing.T) {
+	hub := NewHub()
+
+	sub1 := hub.Subscribe("event-1", 1)
+	sub2 := hub.Subscribe("event-1", 1)
+	other := hub.Subscribe("event-2", 1)
+	defer sub1.Close()
+	defer sub2.Close()
+	defer other.Close()
+
+	delivered := hub.Publish("event-1", "checked_in")
+	if delivered != 2 {
+		t.Errorf("Expected 2 subscribers to receive message, got %d", delivered)
+	}
+
+	for _, sub := range []*Subscription{sub1, sub2} {
+		if msg := <-sub.C(); msg != "checked_in" {
+			t.Errorf("Expected message checked_in, got %v", msg)
+		}
+	}
+
+	select {
+	case msg := <-other.C():
+		t.Errorf("Subscriber of other topic should not receive message, got %v", msg)
+	default:
+	}
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	hub := NewHub()
+
+	sub := hub.Subscribe("event-1", 1)
+	defer sub.Close()
+
+	hub.Publish("event-1", 1)
+
+	// Buffer sudah penuh, message kedua dibuang
+	if delivered := hub.Publish("event-1", 2); delivered != 0 {
+		t.Errorf("Expected message to be dropped, delivered to %d", delivered)
+	}
+
+	if msg := <-sub.C(); msg != 1 {
+		t.Errorf("Expected first message, got %v", msg)
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub()
+
+	sub := hub.Subscribe("event-1", 1)
+	if !hub.HasSubscribers("event-1") {
+		t.Fatal("Expected topic to have subscribers")
+	}
+
+	sub.Close()
+	sub.Close() // aman dipanggil dua kali
+
+	if hub.HasSubscribers("event-1") {
+		t.Error("Expected topic to have no subscribers after close")
+	}
+
+	if _, ok := <-sub.C(); ok {
+		t.Error("Expected channel to be closed")
+	}
+
+	if delivered := hub.Publish("event-1", "x"); delivered != 0 {
+		t.Errorf("Expected no delivery after close, got %d", delivered)
+	}
+}
+
+func TestHub_ConcurrentPublishAndClose(t *testing.T) {
+	hub := NewHub()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		sub := hub.Subscribe("event-1", 4)
+		go func() {
+			defer wg.Done()
+			hub.Publish("event-1", "msg")
+		}()
+		go func() {
+			defer wg.Done()
+			sub.Close()
+		}()
+	}
+
+	wg.Wait()
+
+	if hub.HasSubscribers("event-1") {
+		t.Error("Expected all subscribers to be closed")
+	}
+}
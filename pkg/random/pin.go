@@ -6,17 +6,31 @@ import (
 	"math/big"
 )
 
+const (
+	MinPINLength = 4
+	MaxPINLength = 8
+)
+
 // GeneratePIN mengasilkan random 4 digit PIN
 func GeneratePIN() (string, error) {
-	// Generate angka acak antara 0-9999
-	max := big.NewInt(10000)
+	return GeneratePINWithLength(MinPINLength)
+}
+
+// GeneratePINWithLength menghasilkan random PIN dengan panjang 4 sampai 8 digit
+func GeneratePINWithLength(length int) (string, error) {
+	if length < MinPINLength || length > MaxPINLength {
+		return "", fmt.Errorf("PIN length must be between %d and %d", MinPINLength, MaxPINLength)
+	}
+
+	// Generate angka acak antara 0 sampai 10^length - 1
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
 	n, err := rand.Int(rand.Reader, max)
 	if err != nil {
 		return "", fmt.Errorf("failed generate random number: %v", err)
 	}
 
 	// Format leading zeros
-	pin := fmt.Sprintf("%04d", n.Int64())
+	pin := fmt.Sprintf("%0*d", length, n.Int64())
 	return pin, nil
 }
 
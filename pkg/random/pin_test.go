@@ -55,6 +55,35 @@ func TestGeneratePIN_LeadingZeros(t *testing.T) {
 	t.Log("✅ All PINs have correct length with leading zeros")
 }
 
+func TestGeneratePINWithLength(t *testing.T) {
+	for length := MinPINLength; length <= MaxPINLength; length++ {
+		pin, err := GeneratePINWithLength(length)
+		if err != nil {
+			t.Fatal("Failed to generate PIN:", err)
+		}
+
+		if len(pin) != length {
+			t.Errorf("PIN length should be %d, got %d: %s", length, len(pin), pin)
+		}
+
+		for _, char := range pin {
+			if char < '0' || char > '9' {
+				t.Errorf("PIN should contain only digits, got: %s", pin)
+			}
+		}
+	}
+
+	t.Log("✅ Generated PINs for every supported length")
+}
+
+func TestGeneratePINWithLength_InvalidLength(t *testing.T) {
+	for _, length := range []int{0, 3, 9} {
+		if _, err := GeneratePINWithLength(length); err == nil {
+			t.Errorf("Expected error for PIN length %d", length)
+		}
+	}
+}
+
 func TestGenerateToken(t *testing.T) {
 	// Generate multiple tokens
 	tokens := make(map[string]bool)
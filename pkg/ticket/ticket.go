@@ -0,0 +1,132 @@
+// Package ticket membuat dan memverifikasi payload QR tiket yang ditandatangani Ed25519
+// Format: EC1.<base64url payload>.<base64url signature>
+// Payload berisi event ID dan participant ID, sehingga scanner yang menyimpan
+// public key event bisa memvalidasi tiket tanpa menghubungi server
+package ticket
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Prefix penanda QR dengan format signed versi 1
+const Prefix = "EC1."
+
+var (
+	ErrMalformedTicket  = errors.New("malformed ticket payload")
+	ErrInvalidSignature = errors.New("invalid ticket signature")
+	ErrInvalidKey       = errors.New("invalid ticket key")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Claims isi payload tiket, nama field dibuat pendek supaya QR tetap kecil
+type Claims struct {
+	EventID       string `json:"e"`
+	ParticipantID int64  `json:"p"`
+}
+
+// GenerateKeyPair membuat key pair Ed25519 baru untuk event
+// Private key disimpan dalam bentuk seed 32 byte, keduanya di encode base64url
+func GenerateKeyPair() (publicKey string, privateKey string, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate ticket key: %w", err)
+	}
+
+	return encoding.EncodeToString(pub), encoding.EncodeToString(priv.Seed()), nil
+}
+
+// Sign membuat payload QR yang ditandatangani private key event
+func Sign(privateKey string, claims *Claims) (string, error) {
+	seed, err := encoding.DecodeString(privateKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return "", ErrInvalidKey
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode ticket payload: %w", err)
+	}
+
+	signature := ed25519.Sign(ed25519.NewKeyFromSeed(seed), payload)
+
+	return Prefix + encoding.EncodeToString(payload) + "." + encoding.EncodeToString(signature), nil
+}
+
+// IsSigned return true jika token memakai format signed, bukan token hex lama
+func IsSigned(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// Parse membaca claims tanpa memverifikasi signature
+// Dipakai untuk mengetahui event sebelum public key nya diambil
+func Parse(token string) (*Claims, error) {
+	payload, _, err := split(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeClaims(payload)
+}
+
+// Verify memverifikasi signature tiket dengan public key event lalu mengembalikan claims
+func Verify(publicKey, token string) (*Claims, error) {
+	key, err := encoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	payload, signature, err := split(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(key), payload, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	return decodeClaims(payload)
+}
+
+// split memisahkan payload dan signature dari token
+func split(token string) ([]byte, []byte, error) {
+	if !IsSigned(token) {
+		return nil, nil, ErrMalformedTicket
+	}
+
+	parts := strings.Split(strings.TrimPrefix(token, Prefix), ".")
+	if len(parts) != 2 {
+		return nil, nil, ErrMalformedTicket
+	}
+
+	payload, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, ErrMalformedTicket
+	}
+
+	signature, err := encoding.DecodeString(parts[1])
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return nil, nil, ErrMalformedTicket
+	}
+
+	return payload, signature, nil
+}
+
+func decodeClaims(payload []byte) (*Claims, error) {
+	claims := &Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrMalformedTicket
+	}
+
+	if claims.EventID == "" || claims.ParticipantID == 0 {
+		return nil, ErrMalformedTicket
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,107 @@
+package ticket
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	publicKey, privateKey, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal("Failed to generate key pair:", err)
+	}
+
+	claims := &Claims{
+		EventID:       "3f2b8c1e-9d4a-4b6e-8f1a-2c3d4e5f6a7b",
+		ParticipantID: 42,
+	}
+
+	token, err := Sign(privateKey, claims)
+	if err != nil {
+		t.Fatal("Failed to sign ticket:", err)
+	}
+
+	if !IsSigned(token) {
+		t.Errorf("Token should start with %s, got %s", Prefix, token)
+	}
+
+	verified, err := Verify(publicKey, token)
+	if err != nil {
+		t.Fatal("Failed to verify ticket:", err)
+	}
+
+	if *verified != *claims {
+		t.Errorf("Expected claims %+v, got %+v", claims, verified)
+	}
+
+	t.Log("Signed ticket:", token)
+}
+
+func TestVerify_WrongKey(t *testing.T) {
+	_, privateKey, _ := GenerateKeyPair()
+	otherPublicKey, _, _ := GenerateKeyPair()
+
+	token, _ := Sign(privateKey, &Claims{EventID: "event-1", ParticipantID: 1})
+
+	_, err := Verify(otherPublicKey, token)
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerify_TamperedPayload(t *testing.T) {
+	publicKey, privateKey, _ := GenerateKeyPair()
+
+	token, _ := Sign(privateKey, &Claims{EventID: "event-1", ParticipantID: 1})
+	forged, _ := Sign(privateKey, &Claims{EventID: "event-1", ParticipantID: 2})
+
+	// Payload dari tiket lain dengan signature tiket asli
+	parts := strings.Split(strings.TrimPrefix(token, Prefix), ".")
+	forgedParts := strings.Split(strings.TrimPrefix(forged, Prefix), ".")
+	tampered := Prefix + forgedParts[0] + "." + parts[1]
+
+	_, err := Verify(publicKey, tampered)
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	_, privateKey, _ := GenerateKeyPair()
+
+	token, _ := Sign(privateKey, &Claims{EventID: "event-1", ParticipantID: 7})
+
+	claims, err := Parse(token)
+	if err != nil {
+		t.Fatal("Failed to parse ticket:", err)
+	}
+
+	if claims.EventID != "event-1" || claims.ParticipantID != 7 {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+}
+
+func TestParse_Malformed(t *testing.T) {
+	tokens := []string{
+		"",
+		"a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4", // token hex lama
+		Prefix,
+		Prefix + "abc",
+		Prefix + "!!!.???",
+		Prefix + "e30.AAAA",
+	}
+
+	for _, token := range tokens {
+		if _, err := Parse(token); !errors.Is(err, ErrMalformedTicket) {
+			t.Errorf("Parse(%q) expected ErrMalformedTicket, got %v", token, err)
+		}
+	}
+}
+
+func TestSign_InvalidKey(t *testing.T) {
+	_, err := Sign("not-a-key", &Claims{EventID: "event-1", ParticipantID: 1})
+	if !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Expected ErrInvalidKey, got %v", err)
+	}
+}
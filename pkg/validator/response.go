@@ -32,6 +32,15 @@ func CreatedResponse(c *gin.Context, message string, data any) {
 	})
 }
 
+// AcceptedResponse mengirim response untuk proses yang dilanjutkan di background dengan HTTP 202
+func AcceptedResponse(c *gin.Context, message string, data any) {
+	c.JSON(http.StatusAccepted, Response{
+		Status:  true,
+		Message: message,
+		Data:    data,
+	})
+}
+
 // ErrorResponse mengirim response error dengan HTTP custom
 func ErrorResponse(c *gin.Context, statusCode int, message string) {
 	c.JSON(statusCode, Response{
@@ -73,3 +82,14 @@ func ValidateErrorResponse(c *gin.Context, err any) {
 		Data:    err,
 	})
 }
+
+// ConflictResponse mengirim response conflict dengan HTTP 409
+// data tetap dikirim supaya client tahu kondisi yang menyebabkan conflict
+func ConflictResponse(c *gin.Context, message string, data any) {
+	c.JSON(http.StatusConflict, Response{
+		Status:  false,
+		Message: "Error",
+		Data:    data,
+		Error:   message,
+	})
+}